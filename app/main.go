@@ -1,72 +1,397 @@
 package main
 
 import (
+    "context"
     "database/sql"
-    "fmt"
+    "flag"
+    "github.com/tolbier/go-clean-arch/config"
+    apikey3 "github.com/tolbier/go-clean-arch/delivery/http/apikey"
     article3 "github.com/tolbier/go-clean-arch/delivery/http/article"
+    author3 "github.com/tolbier/go-clean-arch/delivery/http/author"
+    category3 "github.com/tolbier/go-clean-arch/delivery/http/category"
+    health2 "github.com/tolbier/go-clean-arch/delivery/http/health"
+    "github.com/tolbier/go-clean-arch/delivery/http/problem"
+    apikey2 "github.com/tolbier/go-clean-arch/domain/usecases/apikey"
     article2 "github.com/tolbier/go-clean-arch/domain/usecases/article"
+    author2 "github.com/tolbier/go-clean-arch/domain/usecases/author"
+    category2 "github.com/tolbier/go-clean-arch/domain/usecases/category"
+    "github.com/tolbier/go-clean-arch/domain/repositories"
+    libcache "github.com/tolbier/go-clean-arch/lib/cache"
+    "github.com/tolbier/go-clean-arch/lib/health"
+    libmetrics "github.com/tolbier/go-clean-arch/lib/metrics"
+    "github.com/tolbier/go-clean-arch/lib/ratelimit"
+    "github.com/tolbier/go-clean-arch/lib/repository"
+    "github.com/tolbier/go-clean-arch/lib/tracing"
+    "github.com/tolbier/go-clean-arch/repository/cache"
+    "github.com/tolbier/go-clean-arch/repository/mysql/apikey"
     "github.com/tolbier/go-clean-arch/repository/mysql/article"
     "github.com/tolbier/go-clean-arch/repository/mysql/author"
+    "github.com/tolbier/go-clean-arch/repository/memory"
+    repoMetrics "github.com/tolbier/go-clean-arch/repository/metrics"
+    "github.com/tolbier/go-clean-arch/repository/mysql/category"
+    "github.com/tolbier/go-clean-arch/repository/postgres"
+    "github.com/tolbier/go-clean-arch/repository/sqlite"
+    repoTracing "github.com/tolbier/go-clean-arch/repository/tracing"
     "log"
-    "net/url"
-    "time"
+    "os"
 
+    "github.com/go-redis/redis"
     _ "github.com/go-sql-driver/mysql"
+    _ "github.com/lib/pq"
     "github.com/labstack/echo"
-    "github.com/spf13/viper"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+    "github.com/sirupsen/logrus"
 
     _articleHttpDeliveryMiddleware "github.com/tolbier/go-clean-arch/delivery/http/middleware"
 )
 
-func init() {
-	viper.SetConfigFile(`config.json`)
-	err := viper.ReadInConfig()
+// defaultConfigPath is the configuration file read when the -config flag is not given
+func defaultConfigPath() string {
+	if path := os.Getenv(config.EnvPrefix + "_CONFIG"); path != "" {
+		return path
+	}
+	return "config.json"
+}
+
+func configureLogger(cfg config.Config) {
+	switch cfg.Log.Format {
+	case "json":
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	case "text":
+		logrus.SetFormatter(&logrus.TextFormatter{})
+	}
+
+	l, err := logLevel(cfg)
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
+	logrus.SetLevel(l)
+}
 
-	if viper.GetBool(`debug`) {
-		log.Println("Service RUN on DEBUG mode")
+// logLevel will return the lowest level logged: log.level, or else debug or
+// info depending on debug
+func logLevel(cfg config.Config) (logrus.Level, error) {
+	level := cfg.Log.Level
+	if level == "" {
+		level = "info"
+		if cfg.Debug {
+			level = "debug"
+		}
 	}
+	return logrus.ParseLevel(level)
 }
 
-func main() {
-	dbHost := viper.GetString(`database.host`)
-	dbPort := viper.GetString(`database.port`)
-	dbUser := viper.GetString(`database.user`)
-	dbPass := viper.GetString(`database.pass`)
-	dbName := viper.GetString(`database.name`)
-	connection := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPass, dbHost, dbPort, dbName)
-	val := url.Values{}
-	val.Add("parseTime", "1")
-	val.Add("loc", "Asia/Jakarta")
-	dsn := fmt.Sprintf("%s?%s", connection, val.Encode())
-	dbConn, err := sql.Open(`mysql`, dsn)
+func jwtConfig(j config.JWT) _articleHttpDeliveryMiddleware.JWTConfig {
+	return _articleHttpDeliveryMiddleware.JWTConfig{
+		Secret:   []byte(j.Secret),
+		JWKSFile: j.JWKSFile,
+		Issuer:   j.Issuer,
+		Audience: j.Audience,
+	}
+}
+
+func loadJWTKeys(j config.JWT) *_articleHttpDeliveryMiddleware.JWTKeys {
+	if j.Secret == "" && j.JWKSFile == "" {
+		log.Println("neither auth.jwt.secret nor auth.jwt.jwks_file is set, the articles cannot be written")
+	}
+
+	keys, err := _articleHttpDeliveryMiddleware.LoadJWTKeys(jwtConfig(j))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return keys
+}
+
+func loadCORSPolicy(c config.CORS) *_articleHttpDeliveryMiddleware.CORSPolicy {
+	policy, err := _articleHttpDeliveryMiddleware.NewCORSPolicy(_articleHttpDeliveryMiddleware.CORSConfig(c))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return policy
+}
+
+// configurePool will apply the limits of the pool to dbConn
+func configurePool(dbConn *sql.DB, pool config.Pool) {
+	dbConn.SetMaxOpenConns(pool.MaxOpen)
+	dbConn.SetMaxIdleConns(pool.MaxIdle)
+	dbConn.SetConnMaxLifetime(pool.MaxLifetime.Duration())
+}
+
+func openMysql(d config.Database) *sql.DB {
+	dbConn, err := tracing.Open(`mysql`, d.MySQLDSN())
 
 	if err != nil {
 		log.Fatal(err)
 	}
+	configurePool(dbConn, d.Pool)
 	err = dbConn.Ping()
 	if err != nil {
 		log.Fatal(err)
 	}
+	return dbConn
+}
+
+func openPostgres(d config.Database) *sql.DB {
+	dbConn, err := tracing.Open(`postgres`, d.PostgresDSN())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+	configurePool(dbConn, d.Pool)
+	err = dbConn.Ping()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return dbConn
+}
+
+func openSqlite(d config.Database) *sql.DB {
+	dbConn, err := sqlite.Open(d.SQLite.Path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return dbConn
+}
+
+func newRedisClient(r config.Redis) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:     r.Address,
+		Password: r.Password,
+		DB:       r.DB,
+	})
+	if err := client.Ping().Err(); err != nil {
+		log.Fatal(err)
+	}
+	return client
+}
+
+// openCache will return the cache configured by cache.driver, nil when caching is disabled.
+// A Redis server is registered to checker and res.
+func openCache(c config.Cache, checker *health.Checker, res *resources) libcache.Cache {
+	switch c.Driver {
+	case "lru":
+		return libcache.NewLRU(c.Size)
+	case "redis":
+		client := newRedisClient(c.Redis)
+		checker.Register("cache", health.PingRedis(client))
+		res.add("cache", client.Close)
+		return libcache.NewRedis(client)
+	default:
+		return nil
+	}
+}
 
-	defer func() {
-		err := dbConn.Close()
+func openRateLimiter(r config.RateLimit, checker *health.Checker, res *resources) ratelimit.Limiter {
+	switch r.Driver {
+	case "memory":
+		return ratelimit.NewMemory()
+	case "redis":
+		client := newRedisClient(r.Redis)
+		checker.Register("rate_limit", health.PingRedis(client))
+		res.add("rate_limit", client.Close)
+		return ratelimit.NewRedis(client)
+	default:
+		return nil
+	}
+}
+
+func loadRateLimits(r config.RateLimit) _articleHttpDeliveryMiddleware.RateLimits {
+	return _articleHttpDeliveryMiddleware.RateLimits{
+		Default:    r.Default,
+		Routes:     r.Routes,
+		PreAuth:    r.PreAuth,
+		TrustProxy: r.TrustProxy,
+	}
+}
+
+// setupTracing will install the exporter configured by tracing.exporter, the
+// returned function flushes the spans not exported yet
+func setupTracing(t config.Tracing) func() {
+	shutdown, err := tracing.Setup(tracing.Config{
+		Exporter:    t.Exporter,
+		OTLPAddress: t.OTLPAddress,
+		SampleRatio: t.SampleRatio,
+		ServiceName: t.ServiceName,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return shutdown
+}
+
+func main() {
+	configPath := flag.String("config", defaultConfigPath(), "path of the JSON configuration file, $APP_CONFIG by default")
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) > 0 && args[0] == "config" {
+		if err := runConfig(os.Stdout, *configPath, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+	if cfg.Debug {
+		log.Println("Service RUN on DEBUG mode")
+	}
+	configureLogger(cfg)
+
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := runMigrate(os.Stdout, cfg.Database, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var (
+		ar           repositories.ArticleRepository
+		authorRepo   repositories.AuthorRepository
+		categoryRepo repositories.CategoryRepository
+		apiKeyRepo   repositories.APIKeyRepository
+	)
+
+	appMetrics, err := libmetrics.New(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatal(err)
+	}
+	res := &resources{}
+	shutdownTracing := setupTracing(cfg.Tracing)
+	// flushed last, once the spans of everything else are ended
+	res.add("tracing", func() error {
+		shutdownTracing()
+		return nil
+	})
+
+	checker := health.NewChecker(cfg.Health.Timeout.Duration())
+
+	switch driver := cfg.Database.Driver; driver {
+	case "memory":
+		log.Println("Service RUN on in-memory storage, data is lost on exit")
+		db := memory.NewDB()
+		authorRepo = memory.NewAuthorRepository(db)
+		ar = memory.NewArticleRepository(db)
+		categoryRepo = memory.NewCategoryRepository(db)
+		apiKeyRepo = memory.NewAPIKeyRepository(db)
+	case "mysql":
+		dbConn := openMysql(cfg.Database)
+		prometheus.MustRegister(libmetrics.NewDBStatsCollector(dbConn, driver))
+		checker.Register("database", health.PingDB(dbConn))
+		res.add("database", dbConn.Close)
+
+		authorRepo = author.NewMysqlAuthorRepository(dbConn)
+		ar = article.NewMysqlArticleRepository(dbConn)
+		categoryRepo = category.NewMysqlCategoryRepository(dbConn)
+		apiKeyRepo = apikey.NewMysqlAPIKeyRepository(dbConn)
+	case "postgres":
+		dbConn := openPostgres(cfg.Database)
+		prometheus.MustRegister(libmetrics.NewDBStatsCollector(dbConn, driver))
+		checker.Register("database", health.PingDB(dbConn))
+		res.add("database", dbConn.Close)
+
+		authorRepo = postgres.NewPostgresAuthorRepository(dbConn)
+		ar = postgres.NewPostgresArticleRepository(dbConn)
+		categoryRepo = postgres.NewPostgresCategoryRepository(dbConn)
+		apiKeyRepo = postgres.NewPostgresAPIKeyRepository(dbConn)
+	case "sqlite":
+		dbConn := openSqlite(cfg.Database)
+		prometheus.MustRegister(libmetrics.NewDBStatsCollector(dbConn, driver))
+		checker.Register("database", health.PingDB(dbConn))
+		res.add("database", dbConn.Close)
+
+		// a single binary deployment has no separate migrate step
+		m, err := newMigrator(driver, dbConn)
+		if err == nil {
+			_, err = m.Up(context.Background())
+		}
 		if err != nil {
 			log.Fatal(err)
 		}
-	}()
+
+		authorRepo = sqlite.NewSqliteAuthorRepository(dbConn)
+		ar = sqlite.NewSqliteArticleRepository(dbConn)
+		categoryRepo = sqlite.NewSqliteCategoryRepository(dbConn)
+		apiKeyRepo = sqlite.NewSqliteAPIKeyRepository(dbConn)
+	}
+
+	ar = repoMetrics.NewInstrumentedArticleRepository(ar, appMetrics)
+	authorRepo = repoMetrics.NewInstrumentedAuthorRepository(authorRepo, appMetrics)
+	ar = repoTracing.NewTracedArticleRepository(ar)
+	authorRepo = repoTracing.NewTracedAuthorRepository(authorRepo)
+	categoryRepo = repoTracing.NewTracedCategoryRepository(categoryRepo)
+	apiKeyRepo = repoTracing.NewTracedAPIKeyRepository(apiKeyRepo)
+
+	if c := openCache(cfg.Cache, checker, res); c != nil {
+		ttl, negativeTTL := cfg.Cache.TTL.Duration(), cfg.Cache.NegativeTTL.Duration()
+		ar = cache.NewCachedArticleRepository(ar, c, ttl, negativeTTL)
+		authorRepo = cache.NewCachedAuthorRepository(authorRepo, c, ttl, negativeTTL)
+		prometheus.MustRegister(libmetrics.NewCacheStatsCollector(c, cfg.Cache.Driver))
+	}
+
+	if secret := cfg.Cursor.Secret; secret != "" {
+		repository.SetCursorKey([]byte(secret))
+	} else {
+		log.Println("cursor.secret is not set, the pagination cursors will not survive a restart")
+	}
 
 	e := echo.New()
+	configureServer(e, cfg.Server)
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	timeoutContext := cfg.Context.Timeout.Duration()
+	// the usecases are kept unwrapped for their timeout to be reloaded
+	var usecases []timeoutSetter
+	kuc := apikey2.NewUsecase(apiKeyRepo, timeoutContext)
+	usecases = append(usecases, kuc.(timeoutSetter))
+	ku := apikey2.NewTracedUsecase(kuc)
 	middL := _articleHttpDeliveryMiddleware.InitMiddleware()
+	middL.SetCORSPolicy(loadCORSPolicy(cfg.CORS))
+	middL.JWTKeys = loadJWTKeys(cfg.Auth.JWT)
+	middL.APIKeys = ku
+	middL.RateLimiter = openRateLimiter(cfg.RateLimit, checker, res)
+	middL.SetRateLimits(loadRateLimits(cfg.RateLimit))
+	middL.UnlimitedPaths = []string{"/healthz", "/readyz", "/metrics"}
+	middL.HTTPMetrics = appMetrics
+	e.Use(middL.RequestID)
+	e.Use(middL.Tracing)
+	e.Use(middL.AccessLog)
+	e.Use(middL.Metrics)
 	e.Use(middL.CORS)
-	authorRepo := author.NewMysqlAuthorRepository(dbConn)
-	ar := article.NewMysqlArticleRepository(dbConn)
+	e.Use(middL.RateLimitIP)
+	e.Use(middL.JWT)
+	e.Use(middL.APIKey)
+	e.Use(middL.RateLimit)
 
-	timeoutContext := time.Duration(viper.GetInt("context.timeout")) * time.Second
-	au := article2.NewUsecase(ar, authorRepo, timeoutContext)
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+	health2.NewHealthHandler(e, checker)
+	apikey3.NewAPIKeyHandler(e, ku)
+	auc := article2.NewUsecase(ar, authorRepo, categoryRepo, timeoutContext)
+	usecases = append(usecases, auc.(timeoutSetter))
+	au := article2.NewTracedUsecase(article2.NewInstrumentedUsecase(auc, appMetrics))
 	article3.NewArticleHandler(e, au)
+	cuc := category2.NewUsecase(categoryRepo, timeoutContext)
+	usecases = append(usecases, cuc.(timeoutSetter))
+	cu := category2.NewTracedUsecase(cuc)
+	category3.NewCategoryHandler(e, cu)
+	aruc := author2.NewUsecase(authorRepo, timeoutContext)
+	usecases = append(usecases, aruc.(timeoutSetter))
+	aru := author2.NewTracedUsecase(aruc)
+	author3.NewAuthorHandler(e, aru, au)
 
-	log.Fatal(e.Start(viper.GetString("server.address")))
+	rl := &reloader{
+		path:       *configPath,
+		current:    cfg,
+		middleware: middL,
+		usecases:   usecases,
+	}
+	if err := rl.watch(res); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := serve(e, cfg.Server, checker, res); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/tolbier/go-clean-arch/config"
+	"github.com/tolbier/go-clean-arch/lib/migrate"
+	mysqlMigrations "github.com/tolbier/go-clean-arch/repository/mysql/migrations"
+	postgresMigrations "github.com/tolbier/go-clean-arch/repository/postgres/migrations"
+	sqliteMigrations "github.com/tolbier/go-clean-arch/repository/sqlite/migrations"
+)
+
+const migrateUsage = "usage: engine migrate up|down|status"
+
+// newMigrator will create the migrator of the given database driver
+func newMigrator(driver string, dbConn *sql.DB) (*migrate.Migrator, error) {
+	var (
+		dialect    migrate.Dialect
+		migrations fs.FS
+	)
+	switch driver {
+	case "", "mysql":
+		dialect, migrations = migrate.MySQL, mysqlMigrations.FS
+	case "postgres":
+		dialect, migrations = migrate.Postgres, postgresMigrations.FS
+	case "sqlite":
+		dialect, migrations = migrate.SQLite, sqliteMigrations.FS
+	default:
+		return nil, fmt.Errorf("database.driver %q has no migrations", driver)
+	}
+	return migrate.New(dbConn, dialect, migrations)
+}
+
+// runMigrate will run the migrate subcommand against the database of d
+func runMigrate(out io.Writer, d config.Database, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	var dbConn *sql.DB
+	driver := d.Driver
+	switch driver {
+	case "mysql":
+		dbConn = openMysql(d)
+	case "postgres":
+		dbConn = openPostgres(d)
+	case "sqlite":
+		dbConn = openSqlite(d)
+	default:
+		return fmt.Errorf("database.driver %q has no migrations", driver)
+	}
+	defer dbConn.Close()
+
+	m, err := newMigrator(driver, dbConn)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migration")
+		}
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %04d_%s\n", migration.Version, migration.Name)
+		}
+	case "down":
+		migration, err := m.Down(ctx)
+		if err == migrate.ErrNoChange {
+			fmt.Fprintln(out, err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "reverted %04d_%s\n", migration.Version, migration.Name)
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range list {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tolbier/go-clean-arch/config"
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+)
+
+// reloadDebounce is how long the watcher waits for the writes to the
+// configuration file to settle, an editor saves it in several steps
+const reloadDebounce = 100 * time.Millisecond
+
+// reloadable lists the settings applied without a restart, a key ending with
+// a dot stands for every setting under it
+var reloadable = []string{
+	"debug",
+	"log.level",
+	"context.timeout",
+	"rate_limit.trust_proxy",
+	"rate_limit.default.",
+	"rate_limit.routes",
+	"rate_limit.pre_auth.",
+	"cors.",
+}
+
+func isReloadable(key string) bool {
+	for _, r := range reloadable {
+		if key == r || strings.HasSuffix(r, ".") && strings.HasPrefix(key, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeoutSetter is implemented by the usecases, their context timeout may be
+// replaced while they serve requests
+type timeoutSetter interface {
+	SetContextTimeout(timeout time.Duration)
+}
+
+// reloader applies the changes of the configuration file to the running
+// service: the log level, the CORS policy, the rate limits and the timeout of
+// the usecases. The other settings are only applied by a restart.
+type reloader struct {
+	path       string
+	middleware *middleware.GoMiddleware
+	usecases   []timeoutSetter
+
+	mu sync.Mutex
+	// current holds the settings in effect
+	current config.Config
+}
+
+// reload will load and validate the configuration file again and apply its
+// reloadable settings at once. An invalid file is not applied at all, the
+// service keeps its current settings.
+func (r *reloader) reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := loadConfig(r.path)
+	if err != nil {
+		logrus.WithError(err).WithField("path", r.path).Error("the configuration is invalid, keeping the current settings")
+		return
+	}
+
+	var applied, restart []string
+	for _, key := range config.Diff(r.current, cfg) {
+		if isReloadable(key) {
+			applied = append(applied, key)
+		} else {
+			restart = append(restart, key)
+		}
+	}
+
+	if len(applied) > 0 {
+		if err := r.apply(cfg); err != nil {
+			logrus.WithError(err).WithField("path", r.path).Error("the configuration is invalid, keeping the current settings")
+			return
+		}
+		logrus.WithField("settings", applied).Info("configuration reloaded")
+	}
+	if len(restart) > 0 {
+		logrus.WithField("settings", restart).Warn("the service must be restarted to apply these settings")
+	}
+	if len(applied) == 0 && len(restart) == 0 {
+		logrus.Debug("the configuration is unchanged")
+	}
+}
+
+// apply will put the reloadable settings of cfg in effect. Everything that can
+// fail is done before anything is changed.
+func (r *reloader) apply(cfg config.Config) error {
+	level, err := logLevel(cfg)
+	if err != nil {
+		return err
+	}
+	policy, err := middleware.NewCORSPolicy(middleware.CORSConfig(cfg.CORS))
+	if err != nil {
+		return err
+	}
+
+	logrus.SetLevel(level)
+	r.middleware.SetCORSPolicy(policy)
+	r.middleware.SetRateLimits(loadRateLimits(cfg.RateLimit))
+	for _, u := range r.usecases {
+		u.SetContextTimeout(cfg.Context.Timeout.Duration())
+	}
+
+	// the settings needing a restart keep their former value, to be reported
+	// again by the next reload
+	r.current.Debug = cfg.Debug
+	r.current.Log.Level = cfg.Log.Level
+	r.current.Context.Timeout = cfg.Context.Timeout
+	r.current.RateLimit.TrustProxy = cfg.RateLimit.TrustProxy
+	r.current.RateLimit.Default = cfg.RateLimit.Default
+	r.current.RateLimit.Routes = cfg.RateLimit.Routes
+	r.current.RateLimit.PreAuth = cfg.RateLimit.PreAuth
+	r.current.CORS = cfg.CORS
+	return nil
+}
+
+// watch will reload the configuration whenever its file changes or the
+// process receives SIGHUP, until res is closed. The directory of the file is
+// watched rather than the file: the editors and the orchestrators replace it,
+// a Kubernetes ConfigMap by switching the symbolic link it is read through.
+func (r *reloader) watch(res *resources) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(r.path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	done := make(chan struct{})
+	res.add("config watcher", func() error {
+		signal.Stop(hup)
+		close(done)
+		return watcher.Close()
+	})
+
+	go r.run(watcher, hup, done)
+	return nil
+}
+
+func (r *reloader) run(watcher *fsnotify.Watcher, hup <-chan os.Signal, done <-chan struct{}) {
+	path := filepath.Clean(r.path)
+	target, _ := filepath.EvalSymlinks(path)
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-done:
+			return
+		case <-hup:
+			logrus.Info("SIGHUP received, reloading the configuration")
+			r.reload()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			changed := filepath.Clean(event.Name) == path
+			if t, _ := filepath.EvalSymlinks(path); t != target {
+				target, changed = t, true
+			}
+			if changed {
+				pending = time.After(reloadDebounce)
+			}
+		case <-pending:
+			pending = nil
+			r.reload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logrus.WithError(err).Warn("watching the configuration file")
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tolbier/go-clean-arch/config"
+	"github.com/tolbier/go-clean-arch/lib/health"
+)
+
+// configureServer will apply the timeouts and the limits of s to the server of e
+func configureServer(e *echo.Echo, s config.Server) {
+	e.Server.ReadTimeout = s.ReadTimeout.Duration()
+	e.Server.WriteTimeout = s.WriteTimeout.Duration()
+	e.Server.IdleTimeout = s.IdleTimeout.Duration()
+	e.Server.MaxHeaderBytes = s.MaxHeaderBytes
+}
+
+// resources holds what main opened and closes once the server is stopped
+type resources struct {
+	closers []namedCloser
+}
+
+type namedCloser struct {
+	name  string
+	close func() error
+}
+
+// add will register the resource called name, closed by close
+func (r *resources) add(name string, close func() error) {
+	r.closers = append(r.closers, namedCloser{name: name, close: close})
+}
+
+// closeAll will close the resources in the reverse order they were added, the
+// ones opened last may depend on the ones opened first. The failures are logged,
+// the other resources are still closed.
+func (r *resources) closeAll() {
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		c := r.closers[i]
+		if err := c.close(); err != nil {
+			logrus.WithError(err).WithField("resource", c.name).Error("closing")
+			continue
+		}
+		logrus.WithField("resource", c.name).Debug("closed")
+	}
+	r.closers = nil
+}
+
+// serve will run the server of e until the process receives SIGINT or SIGTERM.
+// It then fails the readiness probe, waits s.ShutdownDelay for the load balancer
+// to notice, stops accepting connections and lets the requests in flight finish
+// within s.GracePeriod, before closing res.
+func serve(e *echo.Echo, s config.Server, checker *health.Checker, res *resources) error {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	failed := make(chan error, 1)
+	go func() {
+		if err := e.Start(s.Address); err != http.ErrServerClosed {
+			failed <- err
+		}
+	}()
+
+	select {
+	case err := <-failed:
+		res.closeAll()
+		return err
+	case sig := <-quit:
+		logrus.WithField("signal", sig.String()).Info("shutting down")
+	}
+
+	checker.SetShuttingDown()
+	time.Sleep(s.ShutdownDelay.Duration())
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.GracePeriod.Duration())
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		// the grace period is over, the requests still in flight are cut short
+		logrus.WithError(err).Warn("the requests in flight did not finish within the grace period")
+		if err := e.Close(); err != nil {
+			logrus.WithError(err).Error("closing the server")
+		}
+	}
+
+	res.closeAll()
+	logrus.Info("stopped")
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tolbier/go-clean-arch/config"
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+)
+
+const configUsage = "usage: engine [-config path] config validate"
+
+// validateConfig will check cfg, along with the settings only the middlewares
+// can tell valid: the CORS origins and the JWT keys
+func validateConfig(cfg config.Config) error {
+	var errs config.Errors
+	errs.Add(cfg.Validate())
+	if _, err := middleware.NewCORSPolicy(middleware.CORSConfig(cfg.CORS)); err != nil {
+		errs.Add(fmt.Errorf("cors: %v", err))
+	}
+	if _, err := middleware.LoadJWTKeys(jwtConfig(cfg.Auth.JWT)); err != nil {
+		errs.Add(fmt.Errorf("auth.jwt: %v", err))
+	}
+	return errs.Err()
+}
+
+// loadConfig will load and validate the configuration file at path, the
+// returned error lists every problem found
+func loadConfig(path string) (config.Config, error) {
+	cfg, err := config.Load(path)
+	if _, ok := err.(config.Errors); err != nil && !ok {
+		// the file could not be read, there are no settings to check
+		return cfg, err
+	}
+
+	var errs config.Errors
+	errs.Add(err)
+	errs.Add(validateConfig(cfg))
+	return cfg, errs.Err()
+}
+
+// runConfig will run the config subcommand on the configuration file at path
+func runConfig(out io.Writer, path string, args []string) error {
+	if len(args) != 1 || args[0] != "validate" {
+		return errors.New(configUsage)
+	}
+
+	_, err := loadConfig(path)
+	if err == nil {
+		fmt.Fprintf(out, "%s is valid\n", path)
+		return nil
+	}
+
+	errs, ok := err.(config.Errors)
+	if !ok {
+		errs = config.Errors{err}
+	}
+	for _, err := range errs {
+		fmt.Fprintln(out, err)
+	}
+	return fmt.Errorf("%s is invalid", path)
+}
@@ -0,0 +1,316 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tolbier/go-clean-arch/lib/ratelimit"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding the settings:
+// APP_DATABASE_PASS overrides database.pass
+const EnvPrefix = "APP"
+
+// Seconds is a duration written as a number of seconds
+type Seconds float64
+
+// Duration will return s as a time.Duration
+func (s Seconds) Duration() time.Duration {
+	return time.Duration(float64(s) * float64(time.Second))
+}
+
+// Config represent the settings of the service
+type Config struct {
+	Debug     bool      `mapstructure:"debug"`
+	Log       Log       `mapstructure:"log"`
+	Server    Server    `mapstructure:"server"`
+	Context   Context   `mapstructure:"context"`
+	Health    Health    `mapstructure:"health"`
+	Database  Database  `mapstructure:"database"`
+	Cache     Cache     `mapstructure:"cache"`
+	RateLimit RateLimit `mapstructure:"rate_limit"`
+	Tracing   Tracing   `mapstructure:"tracing"`
+	CORS      CORS      `mapstructure:"cors"`
+	Cursor    Cursor    `mapstructure:"cursor"`
+	Auth      Auth      `mapstructure:"auth"`
+}
+
+// Log represent the settings of the logs
+type Log struct {
+	// Level is the lowest level logged, info (or debug with Config.Debug) when empty
+	Level  string `mapstructure:"level"`
+	Format string `mapstructure:"format"`
+}
+
+// Server represent the settings of the HTTP server
+type Server struct {
+	Address        string  `mapstructure:"address"`
+	ReadTimeout    Seconds `mapstructure:"read_timeout"`
+	WriteTimeout   Seconds `mapstructure:"write_timeout"`
+	IdleTimeout    Seconds `mapstructure:"idle_timeout"`
+	MaxHeaderBytes int     `mapstructure:"max_header_bytes"`
+	// ShutdownDelay is how long the instance fails its readiness probe before it
+	// stops accepting connections
+	ShutdownDelay Seconds `mapstructure:"shutdown_delay"`
+	// GracePeriod is how long the requests in flight may take to finish once the
+	// instance stops accepting connections
+	GracePeriod Seconds `mapstructure:"grace_period"`
+}
+
+// Context represent the time limits of the usecases
+type Context struct {
+	Timeout Seconds `mapstructure:"timeout"`
+}
+
+// Health represent the settings of the readiness checks
+type Health struct {
+	Timeout Seconds `mapstructure:"timeout"`
+}
+
+// Database represent the settings of the storage
+type Database struct {
+	Driver string `mapstructure:"driver"`
+	Host   string `mapstructure:"host"`
+	Port   int    `mapstructure:"port"`
+	User   string `mapstructure:"user"`
+	Pass   string `mapstructure:"pass"`
+	// PassFile is the file holding Pass, as a mounted secret
+	PassFile string `mapstructure:"pass_file"`
+	Name     string `mapstructure:"name"`
+	// SSLMode is the sslmode of the PostgreSQL connections
+	SSLMode string `mapstructure:"sslmode"`
+	// TLS is the tls of the MySQL connections: true, false or skip-verify
+	TLS string `mapstructure:"tls"`
+	// Timezone is the IANA time zone the timestamps are read and written in
+	Timezone string `mapstructure:"timezone"`
+	// Charset is the character set of the connections, the one of the server when empty
+	Charset        string  `mapstructure:"charset"`
+	ConnectTimeout Seconds `mapstructure:"connect_timeout"`
+	Pool           Pool    `mapstructure:"pool"`
+	SQLite         SQLite  `mapstructure:"sqlite"`
+}
+
+// Pool represent the limits of the connection pool, zero leaves them unlimited
+type Pool struct {
+	MaxOpen     int     `mapstructure:"max_open"`
+	MaxIdle     int     `mapstructure:"max_idle"`
+	MaxLifetime Seconds `mapstructure:"max_lifetime"`
+}
+
+// SQLite represent the settings of the SQLite storage
+type SQLite struct {
+	Path string `mapstructure:"path"`
+}
+
+// Redis represent the connection to a Redis server
+type Redis struct {
+	Address  string `mapstructure:"address"`
+	Password string `mapstructure:"password"`
+	// PasswordFile is the file holding Password, as a mounted secret
+	PasswordFile string `mapstructure:"password_file"`
+	DB           int    `mapstructure:"db"`
+}
+
+// Cache represent the settings of the cache of the repositories
+type Cache struct {
+	// Driver is lru, redis, or empty to disable caching
+	Driver      string  `mapstructure:"driver"`
+	Size        int     `mapstructure:"size"`
+	TTL         Seconds `mapstructure:"ttl"`
+	NegativeTTL Seconds `mapstructure:"negative_ttl"`
+	Redis       Redis   `mapstructure:"redis"`
+}
+
+// RateLimit represent the quotas of the clients
+type RateLimit struct {
+	// Driver is memory, redis, or empty to disable rate limiting
+	Driver     string          `mapstructure:"driver"`
+	TrustProxy bool            `mapstructure:"trust_proxy"`
+	Default    ratelimit.Limit `mapstructure:"default"`
+	// Routes holds the quotas of some routes by their method and path, as "GET /articles"
+	Routes map[string]ratelimit.Limit `mapstructure:"routes"`
+	// PreAuth is the quota of each client IP, taken before the request is authenticated
+	PreAuth ratelimit.Limit `mapstructure:"pre_auth"`
+	Redis   Redis           `mapstructure:"redis"`
+}
+
+// Tracing represent the settings of the tracing
+type Tracing struct {
+	// Exporter is stdout, otlp, or empty to disable tracing
+	Exporter    string  `mapstructure:"exporter"`
+	OTLPAddress string  `mapstructure:"otlp_address"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	ServiceName string  `mapstructure:"service_name"`
+}
+
+// CORS represent the CORS policy, it converts to middleware.CORSConfig
+type CORS struct {
+	AllowOrigins     []string `mapstructure:"allow_origins"`
+	AllowMethods     []string `mapstructure:"allow_methods"`
+	AllowHeaders     []string `mapstructure:"allow_headers"`
+	ExposeHeaders    []string `mapstructure:"expose_headers"`
+	AllowCredentials bool     `mapstructure:"allow_credentials"`
+	MaxAge           int      `mapstructure:"max_age"`
+}
+
+// Cursor represent the signing of the pagination cursors
+type Cursor struct {
+	Secret string `mapstructure:"secret"`
+	// SecretFile is the file holding Secret, as a mounted secret
+	SecretFile string `mapstructure:"secret_file"`
+}
+
+// Auth represent the authentication of the clients
+type Auth struct {
+	JWT JWT `mapstructure:"jwt"`
+}
+
+// JWT represent the verification of the bearer tokens
+type JWT struct {
+	Secret string `mapstructure:"secret"`
+	// SecretFile is the file holding Secret, as a mounted secret
+	SecretFile string `mapstructure:"secret_file"`
+	JWKSFile   string `mapstructure:"jwks_file"`
+	Issuer     string `mapstructure:"issuer"`
+	Audience   string `mapstructure:"audience"`
+}
+
+// Default will return the settings used when neither the file nor the
+// environment sets them
+func Default() Config {
+	return Config{
+		Log: Log{Format: "json"},
+		Server: Server{
+			Address:        ":9090",
+			ReadTimeout:    10,
+			WriteTimeout:   30,
+			IdleTimeout:    120,
+			MaxHeaderBytes: 1 << 20,
+			GracePeriod:    15,
+		},
+		Context: Context{Timeout: 2},
+		Health:  Health{Timeout: 1},
+		Database: Database{
+			Driver:   "mysql",
+			Host:     "localhost",
+			Port:     3306,
+			Name:     "article",
+			SSLMode:  "disable",
+			Timezone: "UTC",
+			SQLite:   SQLite{Path: "article.db"},
+		},
+		Cache: Cache{
+			Size:        10000,
+			TTL:         60,
+			NegativeTTL: 5,
+		},
+		RateLimit: RateLimit{
+			PreAuth: ratelimit.Limit{Rate: 10, Burst: 20},
+		},
+		Tracing: Tracing{
+			OTLPAddress: "localhost:55680",
+			SampleRatio: 1,
+			ServiceName: "article-service",
+		},
+		CORS: CORS{AllowOrigins: []string{"*"}},
+	}
+}
+
+// Load will read the settings from the JSON file at path, over the defaults,
+// then apply the APP_* environment variables and read the secrets from their
+// files. An empty path reads no file. The settings are not validated.
+func Load(path string) (Config, error) {
+	v := viper.New()
+	setDefaults(v, "", reflect.ValueOf(Default()))
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if path != "" {
+		v.SetConfigFile(path)
+		v.SetConfigType("json")
+		if err := v.ReadInConfig(); err != nil {
+			return Config{}, fmt.Errorf("reading %s: %v", path, err)
+		}
+	}
+
+	var c Config
+	if err := v.Unmarshal(&c); err != nil {
+		return Config{}, fmt.Errorf("decoding %s: %v", path, err)
+	}
+	c.RateLimit.Routes = normalizeRoutes(c.RateLimit.Routes)
+	if c.Database.Driver == "" {
+		// the service ran on MySQL only at first
+		c.Database.Driver = "mysql"
+	}
+
+	var errs Errors
+	errs.Add(readSecret(&c.Database.Pass, c.Database.PassFile, "database.pass"))
+	errs.Add(readSecret(&c.Cache.Redis.Password, c.Cache.Redis.PasswordFile, "cache.redis.password"))
+	errs.Add(readSecret(&c.RateLimit.Redis.Password, c.RateLimit.Redis.PasswordFile, "rate_limit.redis.password"))
+	errs.Add(readSecret(&c.Cursor.Secret, c.Cursor.SecretFile, "cursor.secret"))
+	errs.Add(readSecret(&c.Auth.JWT.Secret, c.Auth.JWT.SecretFile, "auth.jwt.secret"))
+	return c, errs.Err()
+}
+
+// setDefaults will register the fields of value as the defaults of v, by their
+// mapstructure keys. Every key has a default, for the environment variables
+// to override the keys missing from the file as well.
+func setDefaults(v *viper.Viper, prefix string, value reflect.Value) {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := prefix + fieldKey(t.Field(i))
+		field := value.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			setDefaults(v, key+".", field)
+		case (field.Kind() == reflect.Map || field.Kind() == reflect.Slice) && field.IsNil():
+			// viper would not let the file override a nil default
+		default:
+			v.SetDefault(key, field.Interface())
+		}
+	}
+}
+
+// fieldKey will return the key of the setting held by f
+func fieldKey(f reflect.StructField) string {
+	if name := f.Tag.Get("mapstructure"); name != "" {
+		return name
+	}
+	// mapstructure matches the untagged fields by their name, whatever its case
+	return strings.ToLower(f.Name)
+}
+
+// normalizeRoutes will write the methods of the route keys in upper case, as
+// the keys are lower cased once read
+func normalizeRoutes(routes map[string]ratelimit.Limit) map[string]ratelimit.Limit {
+	normalized := make(map[string]ratelimit.Limit, len(routes))
+	for route, limit := range routes {
+		if fields := strings.Fields(route); len(fields) == 2 {
+			route = strings.ToUpper(fields[0]) + " " + fields[1]
+		}
+		normalized[route] = limit
+	}
+	return normalized
+}
+
+// readSecret will set secret to the content of the file at path, when there is one
+func readSecret(secret *string, path string, key string) error {
+	if path == "" {
+		return nil
+	}
+	if *secret != "" {
+		return fmt.Errorf("%s: set either %s or %s_file, not both", key, key, key)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s_file: %v", key, err)
+	}
+	*secret = strings.TrimRight(string(b), "\r\n")
+	return nil
+}
@@ -0,0 +1,176 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/config"
+	"github.com/tolbier/go-clean-arch/lib/ratelimit"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func setenv(t *testing.T, key string, value string) {
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() { os.Unsetenv(key) })
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := config.Load("")
+
+		require.NoError(t, err)
+		d := config.Default()
+		assert.Equal(t, d.Server, c.Server)
+		assert.Equal(t, d.Database, c.Database)
+		assert.Equal(t, d.CORS, c.CORS)
+		assert.NoError(t, c.Validate())
+	})
+	t.Run("file", func(t *testing.T) {
+		path := writeFile(t, "config.json", `{
+			"server": {"address": ":8080", "grace_period": 2.5},
+			"database": {"driver": "postgres", "port": "5432", "pool": {"max_open": 10}},
+			"rate_limit": {"routes": {"GET /articles": {"rate": 5, "burst": 10}}},
+			"cors": {"allow_origins": ["https://app.example.com"]}
+		}`)
+
+		c, err := config.Load(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, ":8080", c.Server.Address)
+		assert.Equal(t, 2500*time.Millisecond, c.Server.GracePeriod.Duration())
+		assert.Equal(t, config.Seconds(30), c.Server.WriteTimeout, "the settings missing from the file keep their default")
+		assert.Equal(t, "postgres", c.Database.Driver)
+		assert.Equal(t, 5432, c.Database.Port)
+		assert.Equal(t, 10, c.Database.Pool.MaxOpen)
+		assert.Equal(t, map[string]ratelimit.Limit{"GET /articles": {Rate: 5, Burst: 10}}, c.RateLimit.Routes)
+		assert.Equal(t, []string{"https://app.example.com"}, c.CORS.AllowOrigins)
+	})
+	t.Run("environment", func(t *testing.T) {
+		path := writeFile(t, "config.json", `{"database": {"host": "mysql", "user": "user"}}`)
+		setenv(t, "APP_DATABASE_HOST", "db.internal")
+		setenv(t, "APP_DATABASE_PORT", "3307")
+		setenv(t, "APP_RATE_LIMIT_DRIVER", "memory")
+		setenv(t, "APP_CORS_ALLOW_ORIGINS", "https://a.example.com,https://b.example.com")
+
+		c, err := config.Load(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal", c.Database.Host)
+		assert.Equal(t, 3307, c.Database.Port)
+		assert.Equal(t, "user", c.Database.User)
+		assert.Equal(t, "memory", c.RateLimit.Driver)
+		assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, c.CORS.AllowOrigins)
+	})
+	t.Run("secret-files", func(t *testing.T) {
+		pass := writeFile(t, "pass", "s3cret\n")
+		secret := writeFile(t, "secret", "jwt-secret")
+		setenv(t, "APP_DATABASE_PASS_FILE", pass)
+		setenv(t, "APP_AUTH_JWT_SECRET_FILE", secret)
+
+		c, err := config.Load("")
+
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", c.Database.Pass)
+		assert.Equal(t, "jwt-secret", c.Auth.JWT.Secret)
+	})
+	t.Run("secret-errors", func(t *testing.T) {
+		setenv(t, "APP_DATABASE_PASS", "inline")
+		setenv(t, "APP_DATABASE_PASS_FILE", writeFile(t, "pass", "s3cret"))
+		setenv(t, "APP_CURSOR_SECRET_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := config.Load("")
+
+		require.Error(t, err)
+		errs, ok := err.(config.Errors)
+		require.True(t, ok)
+		assert.Len(t, errs, 2)
+		assert.Contains(t, err.Error(), "database.pass: set either database.pass or database.pass_file")
+		assert.Contains(t, err.Error(), "cursor.secret_file")
+	})
+	t.Run("missing-file", func(t *testing.T) {
+		_, err := config.Load(filepath.Join(t.TempDir(), "config.json"))
+
+		assert.Error(t, err)
+	})
+	t.Run("invalid-json", func(t *testing.T) {
+		_, err := config.Load(writeFile(t, "config.json", `{"server": `))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	c := config.Default()
+	c.Log.Format = "xml"
+	c.Context.Timeout = 0
+	c.Database.Port = 70000
+	c.Database.Timezone = "Mars/Olympus"
+	c.Database.TLS = "maybe"
+	c.Cache.Driver = "redis"
+	c.RateLimit.Routes = map[string]ratelimit.Limit{"/articles": {Rate: 1, Burst: 1}}
+	c.Tracing.SampleRatio = 2
+
+	err := c.Validate()
+
+	require.Error(t, err)
+	errs, ok := err.(config.Errors)
+	require.True(t, ok)
+	keys := make([]string, len(errs))
+	for i, e := range errs {
+		keys[i] = strings.SplitN(e.Error(), ":", 2)[0]
+	}
+	assert.Equal(t, []string{"log.format", "context.timeout", "database.port", "database.timezone", "database.tls",
+		"cache.redis.address", "rate_limit.routes", "tracing.sample_ratio"}, keys)
+}
+
+func TestDiff(t *testing.T) {
+	a := config.Default()
+	b := config.Default()
+	assert.Empty(t, config.Diff(a, b))
+
+	b.Log.Level = "debug"
+	b.Database.Pool.MaxOpen = 10
+	b.RateLimit.Default.Burst = 5
+	b.RateLimit.Routes = map[string]ratelimit.Limit{"GET /articles": {Rate: 1, Burst: 1}}
+	b.CORS.AllowOrigins = []string{"https://app.example.com"}
+
+	assert.Equal(t, []string{"log.level", "database.pool.max_open", "rate_limit.default.burst", "rate_limit.routes",
+		"cors.allow_origins"}, config.Diff(a, b))
+}
+
+func TestDSN(t *testing.T) {
+	d := config.Default().Database
+	d.Host, d.User, d.Pass = "db", "user", "p@ss"
+	d.Timezone = "Asia/Jakarta"
+	d.Charset = "utf8mb4"
+	d.TLS = "skip-verify"
+	d.ConnectTimeout = 1.5
+
+	t.Run("mysql", func(t *testing.T) {
+		dsn := d.MySQLDSN()
+
+		assert.True(t, strings.HasPrefix(dsn, "user:p@ss@tcp(db:3306)/article?"), dsn)
+		for _, param := range []string{"charset=utf8mb4", "loc=Asia%2FJakarta", "parseTime=true", "timeout=1.5s", "tls=skip-verify"} {
+			assert.Contains(t, dsn, param)
+		}
+	})
+	t.Run("postgres", func(t *testing.T) {
+		d.Port = 5432
+		d.SSLMode = "require"
+
+		assert.Equal(t, "postgres://user:p%40ss@db:5432/article?client_encoding=utf8mb4&connect_timeout=2&sslmode=require&timezone=Asia%2FJakarta",
+			d.PostgresDSN())
+	})
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"reflect"
+)
+
+// Diff will return the keys of the settings which differ between a and b, as
+// "server.address", in the order of the fields. The maps and slices are
+// compared whole: a change to one of the routes of rate_limit.routes is
+// reported as "rate_limit.routes".
+func Diff(a Config, b Config) []string {
+	return diff(nil, "", reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func diff(keys []string, prefix string, a reflect.Value, b reflect.Value) []string {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := prefix + fieldKey(t.Field(i))
+		fa, fb := a.Field(i), b.Field(i)
+		switch {
+		case fa.Kind() == reflect.Struct:
+			keys = diff(keys, key+".", fa, fb)
+		case !reflect.DeepEqual(fa.Interface(), fb.Interface()):
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
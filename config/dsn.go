@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQLDSN will return the data source name of the MySQL database
+func (d Database) MySQLDSN() string {
+	loc, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		// Validate reports it, the DSN of an invalid configuration is not used
+		loc = time.UTC
+	}
+	c := &mysql.Config{
+		User:      d.User,
+		Passwd:    d.Pass,
+		Net:       "tcp",
+		Addr:      net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		DBName:    d.Name,
+		Loc:       loc,
+		TLSConfig: d.TLS,
+		Timeout:   d.ConnectTimeout.Duration(),
+		ParseTime: true,
+	}
+	if d.Charset != "" {
+		c.Params = map[string]string{"charset": d.Charset}
+	}
+	return c.FormatDSN()
+}
+
+// PostgresDSN will return the data source name of the PostgreSQL database
+func (d Database) PostgresDSN() string {
+	params := url.Values{}
+	params.Set("sslmode", d.SSLMode)
+	if d.Timezone != "" {
+		params.Set("timezone", d.Timezone)
+	}
+	if d.Charset != "" {
+		params.Set("client_encoding", d.Charset)
+	}
+	if d.ConnectTimeout > 0 {
+		// lib/pq only takes whole seconds, rounded up not to go below the setting
+		params.Set("connect_timeout", fmt.Sprint(math.Ceil(float64(d.ConnectTimeout))))
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Pass),
+		Host:     net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:     d.Name,
+		RawQuery: params.Encode(),
+	}
+	return dsn.String()
+}
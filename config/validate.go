@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Errors lists every problem found in the settings, for them to be fixed at once
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Err will return e, or nil when it holds no error
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Add will append err to e, unless it is nil. The Errors in err are flattened.
+func (e *Errors) Add(err error) {
+	switch err := err.(type) {
+	case nil:
+	case Errors:
+		*e = append(*e, err...)
+	default:
+		*e = append(*e, err)
+	}
+}
+
+func (e *Errors) addf(key string, format string, args ...interface{}) {
+	*e = append(*e, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+// oneOf will check that the value at key is among values
+func (e *Errors) oneOf(key string, value string, values ...string) {
+	for _, v := range values {
+		if value == v {
+			return
+		}
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	e.addf(key, "%q is not one of %s", value, strings.Join(quoted, ", "))
+}
+
+func (e *Errors) nonNegative(key string, value float64) {
+	if value < 0 {
+		e.addf(key, "must not be negative")
+	}
+}
+
+func (e *Errors) positive(key string, value float64) {
+	if value <= 0 {
+		e.addf(key, "must be positive")
+	}
+}
+
+func (e *Errors) required(key string, value string) {
+	if value == "" {
+		e.addf(key, "is required")
+	}
+}
+
+// Validate will check every setting, it returns Errors listing all the problems found
+func (c Config) Validate() error {
+	var errs Errors
+
+	errs.oneOf("log.format", c.Log.Format, "json", "text")
+	if c.Log.Level != "" {
+		if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+			errs.addf("log.level", "%v", err)
+		}
+	}
+
+	errs.required("server.address", c.Server.Address)
+	errs.nonNegative("server.read_timeout", float64(c.Server.ReadTimeout))
+	errs.nonNegative("server.write_timeout", float64(c.Server.WriteTimeout))
+	errs.nonNegative("server.idle_timeout", float64(c.Server.IdleTimeout))
+	errs.nonNegative("server.max_header_bytes", float64(c.Server.MaxHeaderBytes))
+	errs.nonNegative("server.shutdown_delay", float64(c.Server.ShutdownDelay))
+	errs.nonNegative("server.grace_period", float64(c.Server.GracePeriod))
+	errs.positive("context.timeout", float64(c.Context.Timeout))
+	errs.positive("health.timeout", float64(c.Health.Timeout))
+
+	c.Database.validate(&errs)
+	c.Cache.validate(&errs)
+	c.RateLimit.validate(&errs)
+
+	errs.oneOf("tracing.exporter", c.Tracing.Exporter, "", "stdout", "otlp")
+	if c.Tracing.Exporter == "otlp" {
+		errs.required("tracing.otlp_address", c.Tracing.OTLPAddress)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs.addf("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	errs.nonNegative("cors.max_age", float64(c.CORS.MaxAge))
+	return errs.Err()
+}
+
+func (d Database) validate(errs *Errors) {
+	errs.oneOf("database.driver", d.Driver, "mysql", "postgres", "sqlite", "memory")
+	switch d.Driver {
+	case "mysql", "postgres":
+		errs.required("database.host", d.Host)
+		errs.required("database.name", d.Name)
+		if d.Port <= 0 || d.Port > 65535 {
+			errs.addf("database.port", "%d is not a port", d.Port)
+		}
+		if _, err := time.LoadLocation(d.Timezone); err != nil {
+			errs.addf("database.timezone", "%v", err)
+		}
+		errs.nonNegative("database.connect_timeout", float64(d.ConnectTimeout))
+		errs.nonNegative("database.pool.max_open", float64(d.Pool.MaxOpen))
+		errs.nonNegative("database.pool.max_idle", float64(d.Pool.MaxIdle))
+		errs.nonNegative("database.pool.max_lifetime", float64(d.Pool.MaxLifetime))
+	case "sqlite":
+		errs.required("database.sqlite.path", d.SQLite.Path)
+	}
+	switch d.Driver {
+	case "mysql":
+		errs.oneOf("database.tls", d.TLS, "", "true", "false", "skip-verify")
+	case "postgres":
+		errs.oneOf("database.sslmode", d.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	}
+}
+
+func (c Cache) validate(errs *Errors) {
+	errs.oneOf("cache.driver", c.Driver, "", "lru", "redis")
+	switch c.Driver {
+	case "lru":
+		errs.positive("cache.size", float64(c.Size))
+	case "redis":
+		errs.required("cache.redis.address", c.Redis.Address)
+	}
+	errs.nonNegative("cache.ttl", float64(c.TTL))
+	errs.nonNegative("cache.negative_ttl", float64(c.NegativeTTL))
+}
+
+func (r RateLimit) validate(errs *Errors) {
+	errs.oneOf("rate_limit.driver", r.Driver, "", "memory", "redis")
+	if r.Driver == "redis" {
+		errs.required("rate_limit.redis.address", r.Redis.Address)
+	}
+	errs.nonNegative("rate_limit.default.rate", r.Default.Rate)
+	errs.nonNegative("rate_limit.default.burst", float64(r.Default.Burst))
+	errs.nonNegative("rate_limit.pre_auth.rate", r.PreAuth.Rate)
+	errs.nonNegative("rate_limit.pre_auth.burst", float64(r.PreAuth.Burst))
+	routes := make([]string, 0, len(r.Routes))
+	for route := range r.Routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	for _, route := range routes {
+		limit := r.Routes[route]
+		if len(strings.Fields(route)) != 2 {
+			errs.addf("rate_limit.routes", "%q is not a method and a path as \"GET /articles\"", route)
+		}
+		errs.nonNegative(fmt.Sprintf("rate_limit.routes[%s].rate", route), limit.Rate)
+		errs.nonNegative(fmt.Sprintf("rate_limit.routes[%s].burst", route), float64(limit.Burst))
+	}
+}
@@ -0,0 +1,101 @@
+package apikey
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/delivery/http/validation"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/usecases/apikey"
+)
+
+// IssuedKey represent an api key along with its secret value, only answered
+// when the key is issued
+type IssuedKey struct {
+	entities.APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyHandler  represent the httphandler for the api keys
+type APIKeyHandler struct {
+	KUsecase apikey.Usecase
+}
+
+// NewAPIKeyHandler will initialize the admin/api-keys/ resources endpoint
+func NewAPIKeyHandler(e *echo.Echo, us apikey.Usecase) {
+	handler := &APIKeyHandler{
+		KUsecase: us,
+	}
+	e.GET("/admin/api-keys", handler.FetchAPIKey)
+	e.POST("/admin/api-keys", handler.Issue)
+	e.DELETE("/admin/api-keys/:id", handler.Revoke)
+	e.POST("/admin/api-keys/:id/rotate", handler.Rotate)
+}
+
+// FetchAPIKey will fetch all the api keys, without their secret value
+func (h *APIKeyHandler) FetchAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := h.KUsecase.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// Issue will issue the api key by given request body
+func (h *APIKeyHandler) Issue(c echo.Context) (err error) {
+	var k entities.APIKey
+	err = c.Bind(&k)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+
+	if err = validation.Validate(c, &k); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	key, err := h.KUsecase.Issue(ctx, &k)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, IssuedKey{APIKey: k, Key: key})
+}
+
+// Revoke will revoke the api key by given param
+func (h *APIKeyHandler) Revoke(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	if err = h.KUsecase.Revoke(ctx, int64(idP)); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Rotate will revoke the api key by given param, and issue its replacement
+func (h *APIKeyHandler) Rotate(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	k, key, err := h.KUsecase.Rotate(ctx, int64(idP))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, IssuedKey{APIKey: k, Key: key})
+}
@@ -0,0 +1,95 @@
+package apikey_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/apikey"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/usecases/apikey"
+)
+
+func TestIssue(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Issue", mock.Anything, mock.AnythingOfType("*entities.APIKey")).Run(func(args mock.Arguments) {
+			k := args.Get(1).(*entities.APIKey)
+			k.ID, k.Prefix, k.Hash = 1, "0001", "h1"
+		}).Return("0001.secret", nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/admin/api-keys", strings.NewReader(`{"name":"importer","scopes":["articles:write"],"author_id":2}`))
+		require.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := apikey.APIKeyHandler{
+			KUsecase: mockUCase,
+		}
+		err = handler.Issue(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "0001.secret", res["key"])
+		assert.Equal(t, "0001", res["prefix"])
+		assert.NotContains(t, res, "hash")
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("invalid-scope", func(t *testing.T) {
+		mockUCase := new(Usecase)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/admin/api-keys", strings.NewReader(`{"name":"importer","scopes":["authors:write"]}`))
+		require.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := apikey.APIKeyHandler{
+			KUsecase: mockUCase,
+		}
+		err = handler.Issue(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockUCase.On("Revoke", mock.Anything, int64(1)).Return(domain.ErrForbidden).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/api-keys/1", strings.NewReader(""))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("admin/api-keys/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	handler := apikey.APIKeyHandler{
+		KUsecase: mockUCase,
+	}
+	err = handler.Revoke(c)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
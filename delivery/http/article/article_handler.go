@@ -1,21 +1,31 @@
 package article
 
 import (
+    "encoding/json"
+    "github.com/tolbier/go-clean-arch/delivery/http/middleware"
+    "github.com/tolbier/go-clean-arch/delivery/http/problem"
+    "github.com/tolbier/go-clean-arch/delivery/http/validation"
     "github.com/tolbier/go-clean-arch/domain"
     "github.com/tolbier/go-clean-arch/domain/entities"
     "github.com/tolbier/go-clean-arch/domain/usecases/article"
+    "github.com/tolbier/go-clean-arch/lib/mergepatch"
+    "github.com/tolbier/go-clean-arch/lib/repository"
+    "io/ioutil"
     "net/http"
     "strconv"
+    "strings"
 
     "github.com/labstack/echo"
-    "github.com/sirupsen/logrus"
-    validator "gopkg.in/go-playground/validator.v9"
 )
 
-// ResponseError represent the reseponse error struct
-type ResponseError struct {
-	Message string `json:"message"`
-}
+const (
+	// MIMEApplicationMergePatchJSON is the media type of the JSON Merge Patch (RFC 7396) documents
+	MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+	// HeaderETag is the response header carrying the article's current version
+	HeaderETag = "ETag"
+	// HeaderIfMatch is the conditional request header carrying the article's expected ETag
+	HeaderIfMatch = "If-Match"
+)
 
 // ArticleHandler  represent the httphandler for article
 type ArticleHandler struct {
@@ -27,22 +37,71 @@ func NewArticleHandler(e *echo.Echo, us article.Usecase) {
 	handler := &ArticleHandler{
 		AUsecase: us,
 	}
-	e.GET("/articles", handler.FetchArticle)
-	e.POST("/articles", handler.Store)
-	e.GET("/articles/:id", handler.GetByID)
-	e.DELETE("/articles/:id", handler.Delete)
+	read := middleware.RequireScope(entities.ScopeArticlesRead)
+	write := middleware.RequireScope(entities.ScopeArticlesWrite)
+	e.GET("/articles", handler.FetchArticle, read)
+	e.GET("/articles/search", handler.Search, read)
+	e.POST("/articles", handler.Store, write)
+	e.GET("/articles/:id", handler.GetByID, read)
+	e.PUT("/articles/:id", handler.Update, write)
+	e.PATCH("/articles/:id", handler.Patch, write)
+	e.DELETE("/articles/:id", handler.Delete, write)
+	e.PUT("/articles/:id/categories/:category_id", handler.AttachCategory, write)
+	e.DELETE("/articles/:id/categories/:category_id", handler.DetachCategory, write)
 }
 
-// FetchArticle will fetch the article based on given params
+// FetchArticle will fetch the article based on given params. The order param, asc or desc,
+// only applies to the first page: the cursors of the following pages keep it.
 func (a *ArticleHandler) FetchArticle(c echo.Context) error {
-	numS := c.QueryParam("num")
-	num, _ := strconv.Atoi(numS)
+	num, err := validation.PageSize(c)
+	if err != nil {
+		return err
+	}
+	cursor := c.QueryParam("cursor")
+	category := c.QueryParam("category")
+	ctx := c.Request().Context()
+
+	if cursor == "" {
+		switch c.QueryParam("order") {
+		case "", "asc":
+		case "desc":
+			cursor = repository.StartCursor(true)
+		default:
+			return domain.ErrBadParamInput.WithDetails(map[string]interface{}{"param": "order"})
+		}
+	}
+
+	var (
+		listAr     []entities.Article
+		nextCursor string
+		prevCursor string
+	)
+	if category != "" {
+		listAr, nextCursor, prevCursor, err = a.AUsecase.FetchByCategory(ctx, category, cursor, num)
+	} else {
+		listAr, nextCursor, prevCursor, err = a.AUsecase.Fetch(ctx, cursor, num)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	c.Response().Header().Set(`X-Prev-Cursor`, prevCursor)
+	return c.JSON(http.StatusOK, listAr)
+}
+
+// Search will find the articles matching the q query param, the most relevant first
+func (a *ArticleHandler) Search(c echo.Context) error {
+	num, err := validation.PageSize(c)
+	if err != nil {
+		return err
+	}
 	cursor := c.QueryParam("cursor")
 	ctx := c.Request().Context()
 
-	listAr, nextCursor, err := a.AUsecase.Fetch(ctx, cursor, int64(num))
+	listAr, nextCursor, err := a.AUsecase.Search(ctx, c.QueryParam("q"), cursor, num)
 	if err != nil {
-		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+		return err
 	}
 
 	c.Response().Header().Set(`X-Cursor`, nextCursor)
@@ -53,7 +112,7 @@ func (a *ArticleHandler) FetchArticle(c echo.Context) error {
 func (a *ArticleHandler) GetByID(c echo.Context) error {
 	idP, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+		return domain.ErrNotFound
 	}
 
 	id := int64(idP)
@@ -61,75 +120,210 @@ func (a *ArticleHandler) GetByID(c echo.Context) error {
 
 	art, err := a.AUsecase.GetByID(ctx, id)
 	if err != nil {
-		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+		return err
 	}
 
+	c.Response().Header().Set(HeaderETag, etag(art.Version))
 	return c.JSON(http.StatusOK, art)
 }
 
-func isRequestValid(m *entities.Article) (bool, error) {
-	validate := validator.New()
-	err := validate.Struct(m)
-	if err != nil {
-		return false, err
-	}
-	return true, nil
-}
-
 // Store will store the article by given request body
 func (a *ArticleHandler) Store(c echo.Context) (err error) {
 	var article entities.Article
 	err = c.Bind(&article)
 	if err != nil {
-		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return problem.UnprocessableEntity(err)
 	}
 
-	var ok bool
-	if ok, err = isRequestValid(&article); !ok {
-		return c.JSON(http.StatusBadRequest, err.Error())
+	if err = validation.Validate(c, &article); err != nil {
+		return err
 	}
 
 	ctx := c.Request().Context()
 	err = a.AUsecase.Store(ctx, &article)
 	if err != nil {
-		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+		return err
 	}
 
+	c.Response().Header().Set(HeaderETag, etag(article.Version))
 	return c.JSON(http.StatusCreated, article)
 }
 
+// Update will replace the article by given param and request body
+func (a *ArticleHandler) Update(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	var article entities.Article
+	err = c.Bind(&article)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+	article.ID = int64(idP)
+
+	return a.update(c, &article, 0)
+}
+
+// Patch will partially update the article by given param and JSON Merge Patch request body
+func (a *ArticleHandler) Patch(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(contentType, MIMEApplicationMergePatchJSON) && !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be "+MIMEApplicationMergePatchJSON)
+	}
+
+	patch, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+
+	ctx := c.Request().Context()
+	current, err := a.AUsecase.GetByID(ctx, int64(idP))
+	if err != nil {
+		return err
+	}
+
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	patched, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+
+	var article entities.Article
+	if err = json.Unmarshal(patched, &article); err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+	article.ID = int64(idP)
+
+	// the patch applies to the version read, it must not overwrite a later one
+	return a.update(c, &article, current.Version)
+}
+
+// update will store article, expecting the version of the If-Match header or
+// else readVersion: the version the handler based article on, zero for none
+func (a *ArticleHandler) update(c echo.Context, article *entities.Article, readVersion int64) (err error) {
+	if err = validation.Validate(c, article); err != nil {
+		return err
+	}
+
+	version, ok := parseIfMatch(c.Request().Header.Get(HeaderIfMatch))
+	if !ok {
+		return domain.ErrPreconditionFailed
+	}
+	if version == 0 {
+		version = readVersion
+	}
+	article.Version = version
+
+	ctx := c.Request().Context()
+	err = a.AUsecase.Update(ctx, article)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.AUsecase.GetByID(ctx, article.ID)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(HeaderETag, etag(res.Version))
+	return c.JSON(http.StatusOK, res)
+}
+
 // Delete will delete article by given param
 func (a *ArticleHandler) Delete(c echo.Context) error {
 	idP, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+		return domain.ErrNotFound
 	}
 
 	id := int64(idP)
 	ctx := c.Request().Context()
 
-	err = a.AUsecase.Delete(ctx, id)
+	version, ok := parseIfMatch(c.Request().Header.Get(HeaderIfMatch))
+	if !ok {
+		return domain.ErrPreconditionFailed
+	}
+
+	err = a.AUsecase.Delete(ctx, id, version)
 	if err != nil {
-		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
-func getStatusCode(err error) int {
-	if err == nil {
-		return http.StatusOK
+// AttachCategory will attach the category to the article by given params
+func (a *ArticleHandler) AttachCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	categoryID, err := strconv.Atoi(c.Param("category_id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	err = a.AUsecase.AttachCategory(ctx, int64(id), int64(categoryID))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DetachCategory will detach the category from the article by given params
+func (a *ArticleHandler) DetachCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	categoryID, err := strconv.Atoi(c.Param("category_id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	err = a.AUsecase.DetachCategory(ctx, int64(id), int64(categoryID))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// etag will build the strong entity tag of the given article version
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseIfMatch will extract the expected article version from the If-Match header.
+// An absent header or "*" yields zero, meaning no particular version is expected.
+// Weak or malformed tags can never match, so they are reported as not ok.
+func parseIfMatch(header string) (version int64, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
 	}
 
-	logrus.Error(err)
-	switch err {
-	case domain.ErrInternalServerError:
-		return http.StatusInternalServerError
-	case domain.ErrNotFound:
-		return http.StatusNotFound
-	case domain.ErrConflict:
-		return http.StatusConflict
-	default:
-		return http.StatusInternalServerError
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
 	}
+	return version, true
 }
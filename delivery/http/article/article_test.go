@@ -3,8 +3,10 @@ package article_test
 import (
     "encoding/json"
     "github.com/tolbier/go-clean-arch/delivery/http/article"
+    "github.com/tolbier/go-clean-arch/delivery/http/problem"
     "github.com/tolbier/go-clean-arch/domain"
     "github.com/tolbier/go-clean-arch/domain/entities"
+    "github.com/tolbier/go-clean-arch/lib/repository"
     "net/http"
     "net/http/httptest"
     "strconv"
@@ -30,7 +32,7 @@ func TestFetch(t *testing.T) {
 	mockListArticle = append(mockListArticle, mockArticle)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(mockListArticle, "10", nil)
+	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(mockListArticle, "10", "1", nil)
 
 	e := echo.New()
 	req, err := http.NewRequest(echo.GET, "/article?num=1&cursor="+cursor, strings.NewReader(""))
@@ -46,15 +48,85 @@ func TestFetch(t *testing.T) {
 
 	responseCursor := rec.Header().Get("X-Cursor")
 	assert.Equal(t, "10", responseCursor)
+	assert.Equal(t, "1", rec.Header().Get("X-Prev-Cursor"))
 	assert.Equal(t, http.StatusOK, rec.Code)
 	mockUCase.AssertExpectations(t)
 }
 
+func TestFetchOrder(t *testing.T) {
+	t.Run("desc", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Fetch", mock.Anything, repository.StartCursor(true), int64(1)).Return([]entities.Article{}, "", "", nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/article?num=1&order=desc", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.FetchArticle(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockUCase := new(Usecase)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/article?num=1&order=newest", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.FetchArticle(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestFetchInvalidNum(t *testing.T) {
+	for _, num := range []string{"-1", "0", "ten"} {
+		t.Run(num, func(t *testing.T) {
+			mockUCase := new(Usecase)
+
+			e := echo.New()
+			req, err := http.NewRequest(echo.GET, "/article?num="+num, strings.NewReader(""))
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := article.ArticleHandler{
+				AUsecase: mockUCase,
+			}
+			err = handler.FetchArticle(c)
+			require.Error(t, err)
+			problem.HTTPErrorHandler(err, c)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var res problem.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, map[string]interface{}{"param": "num"}, res.Details)
+			mockUCase.AssertExpectations(t)
+		})
+	}
+}
+
 func TestFetchError(t *testing.T) {
 	mockUCase := new(Usecase)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(nil, "", domain.ErrInternalServerError)
+	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(nil, "", "", domain.ErrInternalServerError)
 
 	e := echo.New()
 	req, err := http.NewRequest(echo.GET, "/article?num=1&cursor="+cursor, strings.NewReader(""))
@@ -66,11 +138,17 @@ func TestFetchError(t *testing.T) {
 		AUsecase: mockUCase,
 	}
 	err = handler.FetchArticle(c)
-	require.NoError(t, err)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
 
 	responseCursor := rec.Header().Get("X-Cursor")
 	assert.Equal(t, "", responseCursor)
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	var res problem.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, http.StatusInternalServerError, res.Status)
+	assert.Equal(t, domain.CodeInternal, res.Code)
 	mockUCase.AssertExpectations(t)
 }
 
@@ -101,6 +179,7 @@ func TestGetByID(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"`+strconv.FormatInt(mockArticle.Version, 10)+`"`, rec.Header().Get("ETag"))
 	mockUCase.AssertExpectations(t)
 }
 
@@ -149,7 +228,7 @@ func TestDelete(t *testing.T) {
 
 	num := int(mockArticle.ID)
 
-	mockUCase.On("Delete", mock.Anything, int64(num)).Return(nil)
+	mockUCase.On("Delete", mock.Anything, int64(num), int64(0)).Return(nil)
 
 	e := echo.New()
 	req, err := http.NewRequest(echo.DELETE, "/article/"+strconv.Itoa(num), strings.NewReader(""))
@@ -170,3 +249,468 @@ func TestDelete(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 
 }
+
+func TestFetchByCategory(t *testing.T) {
+	var mockArticle entities.Article
+	err := faker.FakeData(&mockArticle)
+	assert.NoError(t, err)
+	mockUCase := new(Usecase)
+	mockListArticle := []entities.Article{mockArticle}
+	mockUCase.On("FetchByCategory", mock.Anything, "food", "", int64(1)).Return(mockListArticle, "10", "", nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/article?num=1&category=food", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := article.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchArticle(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "10", rec.Header().Get("X-Cursor"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestAttachCategory(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockUCase.On("AttachCategory", mock.Anything, int64(1), int64(3)).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/articles/1/categories/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("articles/:id/categories/:category_id")
+	c.SetParamNames("id", "category_id")
+	c.SetParamValues("1", "3")
+	handler := article.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.AttachCategory(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDetachCategoryNotFound(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockUCase.On("DetachCategory", mock.Anything, int64(1), int64(3)).Return(domain.ErrNotFound)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/articles/1/categories/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("articles/:id/categories/:category_id")
+	c.SetParamNames("id", "category_id")
+	c.SetParamValues("1", "3")
+	handler := article.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.DetachCategory(c)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	mockArticle := entities.Article{
+		ID:      3,
+		Title:   "Title",
+		Content: "Content",
+	}
+	j, err := json.Marshal(mockArticle)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*entities.Article")).Return(nil)
+		mockUCase.On("GetByID", mock.Anything, int64(3)).Return(mockArticle, nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/articles/3", strings.NewReader(string(j)))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*entities.Article")).Return(domain.ErrNotFound)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/articles/3", strings.NewReader(string(j)))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Update(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		mockUCase := new(Usecase)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/articles/3", strings.NewReader(`{"title":"Title"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Update(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestPatch(t *testing.T) {
+	mockArticle := entities.Article{
+		ID:      3,
+		Title:   "Title",
+		Content: "Content",
+		Author:  entities.Author{ID: 1},
+		Version: 4,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("GetByID", mock.Anything, int64(3)).Return(mockArticle, nil)
+		// without If-Match the version read is expected, not any version
+		mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(ar *entities.Article) bool {
+			return ar.ID == 3 && ar.Title == "New Title" && ar.Content == "Content" && ar.Author.ID == 1 && ar.Version == 4
+		})).Return(nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PATCH, "/articles/3", strings.NewReader(`{"title":"New Title"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, article.MIMEApplicationMergePatchJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Patch(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("changed-since-read", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("GetByID", mock.Anything, int64(3)).Return(mockArticle, nil)
+		// another write landed between the read and the update
+		mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(ar *entities.Article) bool {
+			return ar.Version == 4
+		})).Return(domain.ErrPreconditionFailed)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PATCH, "/articles/3", strings.NewReader(`{"title":"New Title"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, article.MIMEApplicationMergePatchJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Patch(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("remove-required-field", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("GetByID", mock.Anything, int64(3)).Return(mockArticle, nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PATCH, "/articles/3", strings.NewReader(`{"content":null}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, article.MIMEApplicationMergePatchJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Patch(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("title-conflict", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("GetByID", mock.Anything, int64(3)).Return(mockArticle, nil)
+		mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*entities.Article")).Return(domain.ErrConflict)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PATCH, "/articles/3", strings.NewReader(`{"title":"Taken"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, article.MIMEApplicationMergePatchJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Patch(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("unsupported-media-type", func(t *testing.T) {
+		mockUCase := new(Usecase)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PATCH, "/articles/3", strings.NewReader(`title=Taken`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Patch(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestConditionalUpdate(t *testing.T) {
+	mockArticle := entities.Article{
+		ID:      3,
+		Title:   "Title",
+		Content: "Content",
+		Version: 2,
+	}
+	j, err := json.Marshal(mockArticle)
+	assert.NoError(t, err)
+
+	t.Run("matching-version", func(t *testing.T) {
+		updatedArticle := mockArticle
+		updatedArticle.Version = 3
+		mockUCase := new(Usecase)
+		mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(ar *entities.Article) bool {
+			return ar.Version == 2
+		})).Return(nil)
+		mockUCase.On("GetByID", mock.Anything, int64(3)).Return(updatedArticle, nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/articles/3", strings.NewReader(string(j)))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"2"`)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Update(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("stale-version", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*entities.Article")).Return(domain.ErrPreconditionFailed)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/articles/3", strings.NewReader(string(j)))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Update(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("weak-etag", func(t *testing.T) {
+		mockUCase := new(Usecase)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.PUT, "/articles/3", strings.NewReader(string(j)))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `W/"2"`)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("articles/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Update(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestConditionalDelete(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockUCase.On("Delete", mock.Anything, int64(3), int64(5)).Return(domain.ErrPreconditionFailed)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/articles/3", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("If-Match", `"5"`)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("articles/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := article.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Delete(c)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestSearch(t *testing.T) {
+	mockMatches := []entities.ArticleMatch{
+		{
+			Article: entities.Article{ID: 2, Title: "Makan Ayam", Content: "Ayam goreng"},
+			Score:   1.5,
+			Snippet: "<mark>Ayam</mark> goreng",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Search", mock.Anything, "ayam goreng", "Mg==", int64(1)).Return(mockMatches, "Mw==", nil).Once()
+
+		e := echo.New()
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		e.GET("/articles/search", handler.Search)
+		req, err := http.NewRequest(echo.GET, "/articles/search?q=ayam+goreng&num=1&cursor=Mg%3D%3D", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "Mw==", rec.Header().Get("X-Cursor"))
+		var res []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Len(t, res, 1)
+		assert.Equal(t, "Makan Ayam", res[0]["title"])
+		assert.Equal(t, 1.5, res[0]["score"])
+		assert.Equal(t, "<mark>Ayam</mark> goreng", res[0]["snippet"])
+		mockUCase.AssertExpectations(t)
+	})
+
+	t.Run("error-missing-query", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Search", mock.Anything, "", "", int64(0)).Return(nil, "", domain.ErrBadParamInput).Once()
+
+		e := echo.New()
+		e.HTTPErrorHandler = problem.HTTPErrorHandler
+		handler := article.ArticleHandler{
+			AUsecase: mockUCase,
+		}
+		e.GET("/articles/search", handler.Search)
+		req, err := http.NewRequest(echo.GET, "/articles/search", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		mockUCase.AssertExpectations(t)
+	})
+}
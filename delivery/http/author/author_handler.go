@@ -0,0 +1,171 @@
+package author
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/validation"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/usecases/article"
+	"github.com/tolbier/go-clean-arch/domain/usecases/author"
+)
+
+// AuthorHandler  represent the httphandler for author
+type AuthorHandler struct {
+	AUsecase  author.Usecase
+	ArUsecase article.Usecase
+}
+
+// NewAuthorHandler will initialize the authors/ resources endpoint
+func NewAuthorHandler(e *echo.Echo, us author.Usecase, aus article.Usecase) {
+	handler := &AuthorHandler{
+		AUsecase:  us,
+		ArUsecase: aus,
+	}
+	// renaming or deleting an author, whose articles may be reassigned, is
+	// writing as that author
+	write := middleware.RequireScope(entities.ScopeArticlesWrite)
+	e.GET("/authors", handler.FetchAuthor)
+	e.POST("/authors", handler.Store)
+	e.GET("/authors/:id", handler.GetByID)
+	e.PUT("/authors/:id", handler.Update, write)
+	e.DELETE("/authors/:id", handler.Delete, write)
+	e.GET("/authors/:id/articles", handler.FetchArticles)
+}
+
+// FetchAuthor will fetch the author based on given params
+func (h *AuthorHandler) FetchAuthor(c echo.Context) error {
+	num, err := validation.PageSize(c)
+	if err != nil {
+		return err
+	}
+	cursor := c.QueryParam("cursor")
+	ctx := c.Request().Context()
+
+	list, nextCursor, err := h.AUsecase.Fetch(ctx, cursor, num)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetByID will get author by given id
+func (h *AuthorHandler) GetByID(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	res, err := h.AUsecase.GetByID(ctx, int64(idP))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// FetchArticles will fetch the articles written by the given author
+func (h *AuthorHandler) FetchArticles(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	num, err := validation.PageSize(c)
+	if err != nil {
+		return err
+	}
+	cursor := c.QueryParam("cursor")
+	ctx := c.Request().Context()
+
+	listAr, nextCursor, prevCursor, err := h.ArUsecase.FetchByAuthor(ctx, int64(idP), cursor, num)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	c.Response().Header().Set(`X-Prev-Cursor`, prevCursor)
+	return c.JSON(http.StatusOK, listAr)
+}
+
+// Store will store the author by given request body
+func (h *AuthorHandler) Store(c echo.Context) (err error) {
+	var res entities.Author
+	err = c.Bind(&res)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+
+	if err = validation.Validate(c, &res); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	err = h.AUsecase.Store(ctx, &res)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+// Update will rename the author by given param and request body
+func (h *AuthorHandler) Update(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	var res entities.Author
+	err = c.Bind(&res)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+	res.ID = int64(idP)
+
+	if err = validation.Validate(c, &res); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	err = h.AUsecase.Update(ctx, &res)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// Delete will delete author by given param, optionally moving the author's
+// articles to the author given in the reassign_to query param
+func (h *AuthorHandler) Delete(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	reassignTo := 0
+	if reassignS := c.QueryParam("reassign_to"); reassignS != "" {
+		reassignTo, err = strconv.Atoi(reassignS)
+		if err != nil {
+			return domain.ErrBadParamInput.WithDetails(map[string]interface{}{"param": "reassign_to"})
+		}
+	}
+
+	ctx := c.Request().Context()
+	err = h.AUsecase.Delete(ctx, int64(idP), int64(reassignTo))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,234 @@
+package author_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/author"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/delivery/http/validation"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	articleMocks "github.com/tolbier/go-clean-arch/mocks/domain/usecases/article"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/usecases/author"
+)
+
+func TestFetch(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockList := []entities.Author{{ID: 1, Name: "Iman Tumorang"}}
+	mockUCase.On("Fetch", mock.Anything, "2", int64(1)).Return(mockList, "10", nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/authors?num=1&cursor=2", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := author.AuthorHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchAuthor(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "10", rec.Header().Get("X-Cursor"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchInvalidNum(t *testing.T) {
+	mockUCase := new(Usecase)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/authors?num=-1", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := author.AuthorHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchAuthor(c)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchArticles(t *testing.T) {
+	mockArUCase := new(articleMocks.Usecase)
+	mockList := []entities.Article{{ID: 1, Title: "Hello", Author: entities.Author{ID: 1}}}
+	mockArUCase.On("FetchByAuthor", mock.Anything, int64(1), "", int64(0)).Return(mockList, "10", "", nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/authors/1/articles", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("authors/:id/articles")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	handler := author.AuthorHandler{
+		ArUsecase: mockArUCase,
+	}
+	err = handler.FetchArticles(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "10", rec.Header().Get("X-Cursor"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockArUCase.AssertExpectations(t)
+}
+
+func TestStore(t *testing.T) {
+	mockUCase := new(Usecase)
+
+	j, err := json.Marshal(entities.Author{Name: "Iman Tumorang"})
+	assert.NoError(t, err)
+
+	mockUCase.On("Store", mock.Anything, mock.AnythingOfType("*entities.Author")).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/authors", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/authors")
+
+	handler := author.AuthorHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStoreInvalid(t *testing.T) {
+	mockUCase := new(Usecase)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/authors", strings.NewReader(`{"name":""}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/authors")
+
+	handler := author.AuthorHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var res struct {
+		Details struct {
+			Errors []validation.FieldError `json:"errors"`
+		} `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, []validation.FieldError{
+		{Field: "name", Rule: "required", Message: "name wajib diisi"},
+	}, res.Details.Errors)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("has-articles", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Delete", mock.Anything, int64(1), int64(0)).Return(domain.ErrAuthorHasArticles)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/authors/1", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("authors/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		handler := author.AuthorHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Delete(c)
+		require.Error(t, err)
+		problem.HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("reassign", func(t *testing.T) {
+		mockUCase := new(Usecase)
+		mockUCase.On("Delete", mock.Anything, int64(1), int64(2)).Return(nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.DELETE, "/authors/1?reassign_to=2", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("authors/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		handler := author.AuthorHandler{
+			AUsecase: mockUCase,
+		}
+		err = handler.Delete(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
+
+func TestWriteScope(t *testing.T) {
+	mockUCase := new(Usecase)
+
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	// an API key of author 1 only allowed to read
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p := domain.Principal{Subject: "api-key:0001", AuthorID: 1, Scopes: []string{entities.ScopeArticlesRead}}
+			c.SetRequest(c.Request().WithContext(domain.WithPrincipal(c.Request().Context(), p)))
+			return next(c)
+		}
+	})
+	author.NewAuthorHandler(e, mockUCase, nil)
+
+	t.Run("update", func(t *testing.T) {
+		req, err := http.NewRequest(echo.PUT, "/authors/1", strings.NewReader(`{"name":"Iman"}`))
+		require.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("delete", func(t *testing.T) {
+		req, err := http.NewRequest(echo.DELETE, "/authors/1?reassign_to=2", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,126 @@
+package category
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/validation"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/usecases/category"
+)
+
+// CategoryHandler  represent the httphandler for category
+type CategoryHandler struct {
+	CUsecase category.Usecase
+}
+
+// NewCategoryHandler will initialize the categories/ resources endpoint
+func NewCategoryHandler(e *echo.Echo, us category.Usecase) {
+	handler := &CategoryHandler{
+		CUsecase: us,
+	}
+	write := middleware.RequireScope(entities.ScopeArticlesWrite)
+	e.GET("/categories", handler.FetchCategory)
+	e.POST("/categories", handler.Store, write)
+	e.GET("/categories/:id", handler.GetByID)
+	e.PUT("/categories/:id", handler.Update, write)
+	e.DELETE("/categories/:id", handler.Delete, write)
+}
+
+// FetchCategory will fetch all the categories
+func (h *CategoryHandler) FetchCategory(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := h.CUsecase.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetByID will get category by given id
+func (h *CategoryHandler) GetByID(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	cat, err := h.CUsecase.GetByID(ctx, int64(idP))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cat)
+}
+
+// Store will store the category by given request body
+func (h *CategoryHandler) Store(c echo.Context) (err error) {
+	var cat entities.Category
+	err = c.Bind(&cat)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+
+	if err = validation.Validate(c, &cat); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	err = h.CUsecase.Store(ctx, &cat)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, cat)
+}
+
+// Update will replace the category by given param and request body
+func (h *CategoryHandler) Update(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	var cat entities.Category
+	err = c.Bind(&cat)
+	if err != nil {
+		return problem.UnprocessableEntity(err)
+	}
+	cat.ID = int64(idP)
+
+	if err = validation.Validate(c, &cat); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	err = h.CUsecase.Update(ctx, &cat)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cat)
+}
+
+// Delete will delete category by given param
+func (h *CategoryHandler) Delete(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	err = h.CUsecase.Delete(ctx, int64(idP))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,185 @@
+package category_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/category"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/usecases/category"
+)
+
+func TestFetch(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockList := []entities.Category{{ID: 1, Name: "Makanan", Tag: "food"}}
+	mockUCase.On("Fetch", mock.Anything).Return(mockList, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := category.CategoryHandler{
+		CUsecase: mockUCase,
+	}
+	err = handler.FetchCategory(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByIDNotFound(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockUCase.On("GetByID", mock.Anything, int64(7)).Return(entities.Category{}, domain.ErrNotFound)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories/7", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("categories/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	handler := category.CategoryHandler{
+		CUsecase: mockUCase,
+	}
+	err = handler.GetByID(c)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStore(t *testing.T) {
+	mockCategory := entities.Category{
+		Name: "Makanan",
+		Tag:  "food",
+	}
+	mockUCase := new(Usecase)
+
+	j, err := json.Marshal(mockCategory)
+	assert.NoError(t, err)
+
+	mockUCase.On("Store", mock.Anything, mock.AnythingOfType("*entities.Category")).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/categories", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/categories")
+
+	handler := category.CategoryHandler{
+		CUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdateConflict(t *testing.T) {
+	mockCategory := entities.Category{
+		Name: "Makanan",
+		Tag:  "food",
+	}
+	mockUCase := new(Usecase)
+
+	j, err := json.Marshal(mockCategory)
+	assert.NoError(t, err)
+
+	mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*entities.Category")).Return(domain.ErrConflict)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/categories/2", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("categories/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	handler := category.CategoryHandler{
+		CUsecase: mockUCase,
+	}
+	err = handler.Update(c)
+	require.Error(t, err)
+	problem.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	mockUCase := new(Usecase)
+	mockUCase.On("Delete", mock.Anything, int64(3)).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/categories/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("categories/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := category.CategoryHandler{
+		CUsecase: mockUCase,
+	}
+	err = handler.Delete(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestWriteScope(t *testing.T) {
+	mockUCase := new(Usecase)
+
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	// an API key only allowed to read
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p := domain.Principal{Subject: "api-key:0001", Scopes: []string{entities.ScopeArticlesRead}}
+			c.SetRequest(c.Request().WithContext(domain.WithPrincipal(c.Request().Context(), p)))
+			return next(c)
+		}
+	})
+	category.NewCategoryHandler(e, mockUCase)
+
+	for _, r := range []struct{ method, path string }{
+		{echo.POST, "/categories"},
+		{echo.PUT, "/categories/1"},
+		{echo.DELETE, "/categories/1"},
+	} {
+		req, err := http.NewRequest(r.method, r.path, strings.NewReader(`{"name":"Makanan","tag":"food"}`))
+		require.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code, r.method+" "+r.path)
+	}
+	mockUCase.AssertExpectations(t)
+}
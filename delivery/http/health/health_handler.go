@@ -0,0 +1,43 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/lib/health"
+)
+
+// HealthHandler represent the httphandler for the probes of the orchestrator
+type HealthHandler struct {
+	Checker *health.Checker
+}
+
+// NewHealthHandler will initialize the healthz/ and readyz/ endpoints
+func NewHealthHandler(e *echo.Echo, checker *health.Checker) {
+	handler := &HealthHandler{
+		Checker: checker,
+	}
+	e.GET("/healthz", handler.Liveness)
+	e.GET("/readyz", handler.Readiness)
+}
+
+// Liveness will answer as long as the instance serves requests. It does not
+// check the dependencies, the instance cannot fix them by restarting.
+func (h *HealthHandler) Liveness(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+// Readiness will check every dependency of the instance, answering 503 when
+// one of them fails or the instance is shutting down
+func (h *HealthHandler) Readiness(c echo.Context) error {
+	report := h.Checker.Check(c.Request().Context())
+
+	status := http.StatusOK
+	if !report.OK() {
+		status = http.StatusServiceUnavailable
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(status, report)
+}
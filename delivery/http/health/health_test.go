@@ -0,0 +1,77 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	health2 "github.com/tolbier/go-clean-arch/delivery/http/health"
+	"github.com/tolbier/go-clean-arch/lib/health"
+)
+
+func TestLiveness(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("database", func(ctx context.Context) error { return errors.New("down") })
+	e := echo.New()
+	health2.NewHealthHandler(e, checker)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(echo.GET, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestReadiness(t *testing.T) {
+	var dbErr error
+	checker := health.NewChecker(time.Second)
+	checker.Register("database", func(ctx context.Context) error { return dbErr })
+	checker.Register("cache", func(ctx context.Context) error { return nil })
+	e := echo.New()
+	health2.NewHealthHandler(e, checker)
+
+	get := func(t *testing.T) (int, health.Report) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(echo.GET, "/readyz", nil))
+		var report health.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		return rec.Code, report
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		code, report := get(t)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, health.StatusOK, report.Status)
+		assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, health.StatusOK, report.Checks["cache"].Status)
+	})
+	t.Run("dependency-failing", func(t *testing.T) {
+		dbErr = errors.New("connection refused")
+		defer func() { dbErr = nil }()
+
+		code, report := get(t)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, health.StatusFailing, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["database"].Error)
+		assert.Equal(t, health.StatusOK, report.Checks["cache"].Status)
+	})
+	t.Run("shutting-down", func(t *testing.T) {
+		checker.SetShuttingDown()
+
+		code, report := get(t)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, health.StatusShuttingDown, report.Status)
+	})
+}
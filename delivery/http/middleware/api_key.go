@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+// HeaderAPIKey is the request header carrying the API key of a machine client
+const HeaderAPIKey = "X-API-Key"
+
+// APIKey will authenticate the requests bearing an API key in their X-API-Key
+// header, and put their principal in the request context: it writes as the
+// author of the key, within its scopes. The requests without any go on.
+func (m *GoMiddleware) APIKey(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(HeaderAPIKey)
+		if key == "" {
+			return next(c)
+		}
+		if m.APIKeys == nil {
+			return domain.ErrUnauthorized.WithMessage("API keys are not accepted")
+		}
+
+		req := c.Request()
+		if _, ok := domain.PrincipalFromContext(req.Context()); ok {
+			return domain.ErrUnauthorized.WithMessage("Authenticate with either a bearer token or an API key")
+		}
+		k, err := m.APIKeys.Authenticate(req.Context(), key)
+		if err != nil {
+			return err
+		}
+
+		scopes := k.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+		p := domain.Principal{Subject: "api-key:" + k.Prefix, AuthorID: k.AuthorID, Scopes: scopes}
+		c.SetRequest(req.WithContext(domain.WithPrincipal(req.Context(), p)))
+		return next(c)
+	}
+}
+
+// RequireScope will refuse the requests of the principals not allowed the given
+// scope. The anonymous requests and the unrestricted principals go on.
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p, ok := domain.PrincipalFromContext(c.Request().Context())
+			if ok && !p.HasScope(scope) {
+				return domain.ErrForbidden.WithDetails(map[string]interface{}{"scope": scope})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/usecases/apikey"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/usecases/apikey"
+)
+
+func TestAPIKey(t *testing.T) {
+	mockUCase := new(Usecase)
+	m := middleware.InitMiddleware()
+	m.APIKeys = mockUCase
+
+	serve := func(key string, p *domain.Principal) (res domain.Principal, authenticated bool, err error) {
+		e := echo.New()
+		req := httptest.NewRequest(echo.GET, "/articles", nil)
+		if key != "" {
+			req.Header.Set(middleware.HeaderAPIKey, key)
+		}
+		if p != nil {
+			req = req.WithContext(domain.WithPrincipal(req.Context(), *p))
+		}
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		err = m.APIKey(func(c echo.Context) error {
+			res, authenticated = domain.PrincipalFromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})(c)
+		return
+	}
+
+	t.Run("success", func(t *testing.T) {
+		k := entities.APIKey{ID: 1, Prefix: "0001", AuthorID: 2, Scopes: []string{entities.ScopeArticlesRead}}
+		mockUCase.On("Authenticate", mock.Anything, "0001.secret").Return(k, nil).Once()
+
+		p, ok, err := serve("0001.secret", nil)
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, domain.Principal{Subject: "api-key:0001", AuthorID: 2, Scopes: k.Scopes}, p)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("anonymous", func(t *testing.T) {
+		_, ok, err := serve("", nil)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		mockUCase.On("Authenticate", mock.Anything, "0001.other").Return(entities.APIKey{}, apikey.ErrInvalidKey).Once()
+
+		_, ok, err := serve("0001.other", nil)
+
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+		assert.False(t, ok)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("bearer-token-too", func(t *testing.T) {
+		_, _, err := serve("0001.secret", &domain.Principal{Subject: "1", AuthorID: 1})
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+	})
+}
+
+func TestRequireScope(t *testing.T) {
+	serve := func(ctx context.Context) error {
+		e := echo.New()
+		req := httptest.NewRequest(echo.POST, "/articles", nil).WithContext(ctx)
+		c := e.NewContext(req, httptest.NewRecorder())
+		return middleware.RequireScope(entities.ScopeArticlesWrite)(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})(c)
+	}
+
+	assert.NoError(t, serve(context.TODO()))
+	assert.NoError(t, serve(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1})))
+	assert.NoError(t, serve(domain.WithPrincipal(context.TODO(), domain.Principal{Scopes: []string{entities.ScopeArticlesWrite}})))
+
+	err := serve(domain.WithPrincipal(context.TODO(), domain.Principal{Scopes: []string{entities.ScopeArticlesRead}}))
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+// CORSConfig represent the CORS policy of the API
+type CORSConfig struct {
+	// AllowOrigins are the origins allowed to call the API, as "https://app.example.com",
+	// "https://*.example.com" for every subdomain of example.com, or "*" for any origin
+	AllowOrigins []string `mapstructure:"allow_origins"`
+	// AllowMethods are the methods allowed by the preflight requests, the usual ones when empty
+	AllowMethods []string `mapstructure:"allow_methods"`
+	// AllowHeaders are the request headers allowed by the preflight requests, "*" allows any
+	AllowHeaders []string `mapstructure:"allow_headers"`
+	// ExposeHeaders are the response headers the browsers let the scripts read
+	ExposeHeaders []string `mapstructure:"expose_headers"`
+	// AllowCredentials lets the browsers send the cookies and the Authorization header
+	AllowCredentials bool `mapstructure:"allow_credentials"`
+	// MaxAge is how long, in seconds, the browsers may cache the preflight responses
+	MaxAge int `mapstructure:"max_age"`
+}
+
+// defaultCORSMethods are the methods allowed when CORSConfig.AllowMethods is empty
+var defaultCORSMethods = []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE}
+
+// CORSPolicy is a CORSConfig ready to be applied by the CORS middleware
+type CORSPolicy struct {
+	anyOrigin      bool
+	origins        map[string]bool
+	wildcards      []originWildcard
+	anyHeader      bool
+	allowMethods   string
+	allowHeaders   string
+	exposeHeaders  string
+	credentials    bool
+	maxAge         string
+	allowedMethods map[string]bool
+}
+
+// originWildcard matches the origins made of prefix, one or more subdomain labels and suffix
+type originWildcard struct {
+	prefix string
+	suffix string
+}
+
+func (w originWildcard) match(origin string) bool {
+	if len(origin) <= len(w.prefix)+len(w.suffix) ||
+		!strings.HasPrefix(origin, w.prefix) || !strings.HasSuffix(origin, w.suffix) {
+		return false
+	}
+	sub := origin[len(w.prefix) : len(origin)-len(w.suffix)]
+	for _, label := range strings.Split(sub, ".") {
+		if label == "" || strings.Trim(label, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultCORSPolicy lets any origin call the API, without credentials
+var defaultCORSPolicy, _ = NewCORSPolicy(CORSConfig{AllowOrigins: []string{"*"}})
+
+// NewCORSPolicy will check config and prepare it for the CORS middleware
+func NewCORSPolicy(config CORSConfig) (*CORSPolicy, error) {
+	p := &CORSPolicy{
+		origins:        map[string]bool{},
+		credentials:    config.AllowCredentials,
+		allowedMethods: map[string]bool{},
+	}
+
+	for _, origin := range config.AllowOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		switch {
+		case origin == "*":
+			p.anyOrigin = true
+		case strings.Contains(origin, "*"):
+			i := strings.Index(origin, "://*.")
+			if i < 0 || strings.Count(origin, "*") > 1 {
+				return nil, fmt.Errorf("invalid CORS origin %q, a wildcard stands for the subdomains as in \"https://*.example.com\"", origin)
+			}
+			p.wildcards = append(p.wildcards, originWildcard{prefix: origin[:i+len("://")], suffix: origin[i+len("://*"):]})
+		case !strings.Contains(origin, "://"):
+			return nil, fmt.Errorf("invalid CORS origin %q, expected a scheme and a host as \"https://app.example.com\"", origin)
+		default:
+			p.origins[strings.TrimSuffix(origin, "/")] = true
+		}
+	}
+	if p.anyOrigin && p.credentials {
+		return nil, fmt.Errorf("the credentials cannot be allowed for any origin, list the allowed origins instead of \"*\"")
+	}
+
+	methods := make([]string, 0, len(config.AllowMethods))
+	for _, method := range config.AllowMethods {
+		methods = append(methods, strings.ToUpper(method))
+	}
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	for _, method := range methods {
+		p.allowedMethods[method] = true
+	}
+	p.allowMethods = strings.Join(methods, ", ")
+
+	var headers []string
+	for _, header := range config.AllowHeaders {
+		if header == "*" {
+			p.anyHeader = true
+			continue
+		}
+		headers = append(headers, http.CanonicalHeaderKey(header))
+	}
+	p.allowHeaders = strings.Join(headers, ", ")
+	p.exposeHeaders = strings.Join(config.ExposeHeaders, ", ")
+
+	if config.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(config.MaxAge)
+	}
+	return p, nil
+}
+
+// allowOrigin will return the Access-Control-Allow-Origin value answered to
+// origin, empty when origin may not call the API
+func (p *CORSPolicy) allowOrigin(origin string) string {
+	if p.anyOrigin {
+		return "*"
+	}
+	if origin == "" {
+		return ""
+	}
+	lower := strings.ToLower(origin)
+	if p.origins[lower] {
+		return origin
+	}
+	for _, w := range p.wildcards {
+		if w.match(lower) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// CORS will apply the CORS policy of m, answering the preflight requests of the
+// allowed origins itself. The origins refused get no CORS header, their browser
+// keeps the scripts from reading the responses.
+func (m *GoMiddleware) CORS(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		p := m.corsPolicy()
+		req, header := c.Request(), c.Response().Header()
+		origin := req.Header.Get(echo.HeaderOrigin)
+		preflight := req.Method == echo.OPTIONS && origin != "" &&
+			req.Header.Get(echo.HeaderAccessControlRequestMethod) != ""
+
+		// the answer depends on the origin, unless every origin gets the same
+		if !p.anyOrigin {
+			header.Add(echo.HeaderVary, echo.HeaderOrigin)
+		}
+		allowOrigin := p.allowOrigin(origin)
+
+		if !preflight {
+			if allowOrigin != "" {
+				header.Set(echo.HeaderAccessControlAllowOrigin, allowOrigin)
+				if p.credentials {
+					header.Set(echo.HeaderAccessControlAllowCredentials, "true")
+				}
+				if p.exposeHeaders != "" {
+					header.Set(echo.HeaderAccessControlExposeHeaders, p.exposeHeaders)
+				}
+			}
+			return next(c)
+		}
+
+		header.Add(echo.HeaderVary, echo.HeaderAccessControlRequestMethod)
+		header.Add(echo.HeaderVary, echo.HeaderAccessControlRequestHeaders)
+		if allowOrigin == "" || !p.allowedMethods[strings.ToUpper(req.Header.Get(echo.HeaderAccessControlRequestMethod))] {
+			return c.NoContent(http.StatusNoContent)
+		}
+
+		header.Set(echo.HeaderAccessControlAllowOrigin, allowOrigin)
+		if p.credentials {
+			header.Set(echo.HeaderAccessControlAllowCredentials, "true")
+		}
+		header.Set(echo.HeaderAccessControlAllowMethods, p.allowMethods)
+		allowHeaders := p.allowHeaders
+		if p.anyHeader {
+			allowHeaders = req.Header.Get(echo.HeaderAccessControlRequestHeaders)
+		}
+		if allowHeaders != "" {
+			header.Set(echo.HeaderAccessControlAllowHeaders, allowHeaders)
+		}
+		if p.maxAge != "" {
+			header.Set(echo.HeaderAccessControlMaxAge, p.maxAge)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
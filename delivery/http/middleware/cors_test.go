@@ -0,0 +1,150 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+)
+
+func TestNewCORSPolicy(t *testing.T) {
+	for name, config := range map[string]middleware.CORSConfig{
+		"wildcard-not-subdomain":  {AllowOrigins: []string{"https://app*.example.com"}},
+		"two-wildcards":           {AllowOrigins: []string{"https://*.*.example.com"}},
+		"no-scheme":               {AllowOrigins: []string{"app.example.com"}},
+		"credentials-any-origins": {AllowOrigins: []string{"*"}, AllowCredentials: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := middleware.NewCORSPolicy(config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCORSPolicy(t *testing.T) {
+	policy, err := middleware.NewCORSPolicy(middleware.CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com", "https://*.example.org"},
+		AllowMethods:     []string{"get", "post"},
+		AllowHeaders:     []string{"authorization", "Content-Type"},
+		ExposeHeaders:    []string{"X-Cursor", "X-Prev-Cursor"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	})
+	require.NoError(t, err)
+
+	m := middleware.InitMiddleware()
+	m.SetCORSPolicy(policy)
+	e := echo.New()
+	e.Use(m.CORS)
+	var called bool
+	e.GET("/articles", func(c echo.Context) error {
+		called = true
+		return c.NoContent(http.StatusOK)
+	})
+
+	serve := func(method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+		called = false
+		req := httptest.NewRequest(method, "/articles", nil)
+		if origin != "" {
+			req.Header.Set(echo.HeaderOrigin, origin)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("allowed-origins", func(t *testing.T) {
+		for _, origin := range []string{"https://app.example.com", "https://a.example.org", "https://a.b-c.example.org",
+			"HTTPS://App.Example.com"} {
+			rec := serve(echo.GET, origin, nil)
+
+			assert.True(t, called)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, origin, rec.Header().Get(echo.HeaderAccessControlAllowOrigin), origin)
+			assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+			assert.Equal(t, "X-Cursor, X-Prev-Cursor", rec.Header().Get(echo.HeaderAccessControlExposeHeaders))
+			assert.Equal(t, []string{echo.HeaderOrigin}, rec.Header()[echo.HeaderVary])
+		}
+	})
+	t.Run("refused-origins", func(t *testing.T) {
+		for _, origin := range []string{"https://evil.com", "https://example.org", "http://a.example.org",
+			"https://a.example.org.evil.com", "https://evilexample.org", "https://.example.org", "https://app.example.com:8443"} {
+			rec := serve(echo.GET, origin, nil)
+
+			assert.True(t, called)
+			assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin), origin)
+			assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+			assert.Equal(t, []string{echo.HeaderOrigin}, rec.Header()[echo.HeaderVary])
+		}
+	})
+	t.Run("no-origin", func(t *testing.T) {
+		rec := serve(echo.GET, "", nil)
+
+		assert.True(t, called)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+	t.Run("preflight", func(t *testing.T) {
+		rec := serve(echo.OPTIONS, "https://app.example.com", map[string]string{
+			echo.HeaderAccessControlRequestMethod:  "POST",
+			echo.HeaderAccessControlRequestHeaders: "authorization",
+		})
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+		assert.Equal(t, "GET, POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+		assert.Equal(t, "Authorization, Content-Type", rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+		assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+		assert.Contains(t, rec.Header()[echo.HeaderVary], echo.HeaderOrigin)
+	})
+	t.Run("preflight-refused-origin", func(t *testing.T) {
+		rec := serve(echo.OPTIONS, "https://evil.com", map[string]string{echo.HeaderAccessControlRequestMethod: "POST"})
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	})
+	t.Run("preflight-refused-method", func(t *testing.T) {
+		rec := serve(echo.OPTIONS, "https://app.example.com", map[string]string{echo.HeaderAccessControlRequestMethod: "DELETE"})
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	})
+}
+
+func TestCORSAnyOrigin(t *testing.T) {
+	policy, err := middleware.NewCORSPolicy(middleware.CORSConfig{
+		AllowOrigins: []string{"*"},
+		AllowHeaders: []string{"*"},
+	})
+	require.NoError(t, err)
+	m := middleware.InitMiddleware()
+	m.SetCORSPolicy(policy)
+	e := echo.New()
+	e.Use(m.CORS)
+
+	req := httptest.NewRequest(echo.OPTIONS, "/articles", nil)
+	req.Header.Set(echo.HeaderOrigin, "https://anywhere.com")
+	req.Header.Set(echo.HeaderAccessControlRequestMethod, "PUT")
+	req.Header.Set(echo.HeaderAccessControlRequestHeaders, "X-API-Key, If-Match")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+	assert.Equal(t, "GET, HEAD, PUT, PATCH, POST, DELETE", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	assert.Equal(t, "X-API-Key, If-Match", rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+	assert.NotContains(t, rec.Header()[echo.HeaderVary], echo.HeaderOrigin)
+}
@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+// JWTConfig represent the keys and the claims the bearer tokens are checked against
+type JWTConfig struct {
+	// Secret verifies the HS256 tokens, none are accepted when it is empty
+	Secret []byte
+	// JWKSFile is the path of the JSON Web Key Set verifying the RS256 tokens,
+	// none are accepted when it is empty
+	JWKSFile string
+	// Issuer must match the iss claim, when set
+	Issuer string
+	// Audience must be one of the aud claim, when set
+	Audience string
+}
+
+// JWTKeys verifies the bearer tokens
+type JWTKeys struct {
+	secret   []byte
+	rsaKeys  map[string]*rsa.PublicKey
+	issuer   string
+	audience string
+}
+
+// LoadJWTKeys will load the keys verifying the bearer tokens
+func LoadJWTKeys(config JWTConfig) (*JWTKeys, error) {
+	k := &JWTKeys{
+		secret:   config.Secret,
+		rsaKeys:  map[string]*rsa.PublicKey{},
+		issuer:   config.Issuer,
+		audience: config.Audience,
+	}
+	if config.JWKSFile == "" {
+		return k, nil
+	}
+
+	data, err := ioutil.ReadFile(config.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+	if k.rsaKeys, err = parseJWKS(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", config.JWKSFile, err)
+	}
+	return k, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// parseJWKS will return the RSA signature keys of a JSON Web Key Set by their id
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Alg != "" && key.Alg != "RS256") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid modulus: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("key %q: invalid exponent", key.Kid)
+		}
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RS256 signature key")
+	}
+	return keys, nil
+}
+
+// keyFunc will return the key verifying the signature of the token, after checking
+// the token is signed the way this key expects
+func (k *JWTKeys) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method {
+	case jwt.SigningMethodHS256:
+		if len(k.secret) == 0 {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		return k.secret, nil
+	case jwt.SigningMethodRS256:
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := k.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(k.rsaKeys) == 1 {
+			for _, key := range k.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// Verify will check the signature and the claims of the token, and return the
+// principal it authenticates: its sub claim is the id of the author it writes
+// as, and a true admin claim grants the admin role
+func (k *JWTKeys) Verify(tokenString string) (domain.Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(tokenString, claims, k.keyFunc); err != nil {
+		return domain.Principal{}, err
+	}
+	if _, ok := claims["exp"]; !ok {
+		return domain.Principal{}, errors.New("token has no expiration time")
+	}
+	if k.issuer != "" && !claims.VerifyIssuer(k.issuer, true) {
+		return domain.Principal{}, errors.New("token has an unexpected issuer")
+	}
+	if k.audience != "" && !hasAudience(claims["aud"], k.audience) {
+		return domain.Principal{}, errors.New("token has an unexpected audience")
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return domain.Principal{}, errors.New("token has no subject")
+	}
+	p := domain.Principal{Subject: sub}
+	p.AuthorID, _ = strconv.ParseInt(sub, 10, 64)
+	p.Admin, _ = claims["admin"].(bool)
+	return p, nil
+}
+
+// hasAudience will tell whether the aud claim, a string or a list of them, holds audience
+func hasAudience(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JWT will authenticate the requests bearing a JWT in their Authorization header,
+// and put their principal in the request context. The requests without any go on
+// anonymously, the usecases refuse what they are not allowed to do; those with an
+// invalid token are refused right away.
+func (m *GoMiddleware) JWT(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		auth := c.Request().Header.Get(echo.HeaderAuthorization)
+		if len(auth) < len("Bearer ") || !strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+			return next(c)
+		}
+		if m.JWTKeys == nil {
+			return domain.ErrUnauthorized.WithMessage("Bearer tokens are not accepted")
+		}
+
+		p, err := m.JWTKeys.Verify(strings.TrimSpace(auth[len("Bearer "):]))
+		if err != nil {
+			return domain.ErrUnauthorized.WithMessage("The bearer token is not valid").Wrap(err)
+		}
+
+		req := c.Request()
+		c.SetRequest(req.WithContext(domain.WithPrincipal(req.Context(), p)))
+		return next(c)
+	}
+}
@@ -0,0 +1,167 @@
+package middleware_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+var secret = []byte("secret")
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims, kid string) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func claims(sub string) jwt.MapClaims {
+	return jwt.MapClaims{"sub": sub, "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+// serve will run the JWT middleware on a request with the given Authorization
+// header, and return the principal the handler saw
+func serve(t *testing.T, m *middleware.GoMiddleware, auth string) (p domain.Principal, authenticated bool, err error) {
+	e := echo.New()
+	req := httptest.NewRequest(echo.POST, "/articles", nil)
+	if auth != "" {
+		req.Header.Set(echo.HeaderAuthorization, auth)
+	}
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err = m.JWT(func(c echo.Context) error {
+		p, authenticated = domain.PrincipalFromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})(c)
+	return
+}
+
+func TestJWT(t *testing.T) {
+	keys, err := middleware.LoadJWTKeys(middleware.JWTConfig{Secret: secret, Issuer: "auth", Audience: "articles"})
+	require.NoError(t, err)
+	m := middleware.InitMiddleware()
+	m.JWTKeys = keys
+
+	t.Run("success", func(t *testing.T) {
+		c := claims("1")
+		c["iss"] = "auth"
+		c["aud"] = []string{"articles", "authors"}
+		p, ok, err := serve(t, m, "Bearer "+sign(t, jwt.SigningMethodHS256, secret, c, ""))
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, domain.Principal{Subject: "1", AuthorID: 1}, p)
+
+		c = claims("root")
+		c["iss"], c["aud"], c["admin"] = "auth", "articles", true
+		p, _, err = serve(t, m, "bearer "+sign(t, jwt.SigningMethodHS256, secret, c, ""))
+		require.NoError(t, err)
+		assert.Equal(t, domain.Principal{Subject: "root", Admin: true}, p)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		_, ok, err := serve(t, m, "")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		valid := claims("1")
+		valid["iss"], valid["aud"] = "auth", "articles"
+		expired := jwt.MapClaims{"sub": "1", "iss": "auth", "aud": "articles", "exp": time.Now().Add(-time.Minute).Unix()}
+		noExpiry := jwt.MapClaims{"sub": "1", "iss": "auth", "aud": "articles"}
+		otherIssuer := jwt.MapClaims{"sub": "1", "iss": "other", "aud": "articles", "exp": valid["exp"]}
+		otherAudience := jwt.MapClaims{"sub": "1", "iss": "auth", "aud": "other", "exp": valid["exp"]}
+
+		for name, token := range map[string]string{
+			"bad-signature":  sign(t, jwt.SigningMethodHS256, []byte("other"), valid, ""),
+			"expired":        sign(t, jwt.SigningMethodHS256, secret, expired, ""),
+			"no-expiry":      sign(t, jwt.SigningMethodHS256, secret, noExpiry, ""),
+			"other-issuer":   sign(t, jwt.SigningMethodHS256, secret, otherIssuer, ""),
+			"other-audience": sign(t, jwt.SigningMethodHS256, secret, otherAudience, ""),
+			"other-method":   sign(t, jwt.SigningMethodHS512, secret, valid, ""),
+			"malformed":      "not.a.token",
+		} {
+			_, ok, err := serve(t, m, "Bearer "+token)
+			assert.True(t, errors.Is(err, domain.ErrUnauthorized), name)
+			assert.False(t, ok, name)
+		}
+	})
+}
+
+func TestJWTWithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "jwks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "jwks.json")
+	require.NoError(t, ioutil.WriteFile(path, jwks, 0600))
+
+	keys, err := middleware.LoadJWTKeys(middleware.JWTConfig{JWKSFile: path})
+	require.NoError(t, err)
+	m := middleware.InitMiddleware()
+	m.JWTKeys = keys
+
+	t.Run("success", func(t *testing.T) {
+		p, ok, err := serve(t, m, "Bearer "+sign(t, jwt.SigningMethodRS256, key, claims("2"), "key-1"))
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, int64(2), p.AuthorID)
+
+		// a set of one key verifies the tokens naming none
+		_, ok, err = serve(t, m, "Bearer "+sign(t, jwt.SigningMethodRS256, key, claims("2"), ""))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := serve(t, m, "Bearer "+sign(t, jwt.SigningMethodRS256, key, claims("2"), "key-2"))
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+
+		// no secret is configured, the HS256 tokens are refused
+		_, _, err = serve(t, m, "Bearer "+sign(t, jwt.SigningMethodHS256, []byte(""), claims("2"), ""))
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+	})
+
+	t.Run("invalid-file", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{"keys":[]}`), 0600))
+		_, err := middleware.LoadJWTKeys(middleware.JWTConfig{JWKSFile: path})
+		assert.Error(t, err)
+
+		_, err = middleware.LoadJWTKeys(middleware.JWTConfig{JWKSFile: filepath.Join(dir, "missing.json")})
+		assert.Error(t, err)
+	})
+}
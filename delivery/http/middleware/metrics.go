@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo"
+)
+
+// routeUnmatched labels the requests matching none of the routes, their
+// paths are not recorded not to make up a label value for each of them
+const routeUnmatched = "unmatched"
+
+// routeSet holds the paths of the routes of an echo instance, gathered at
+// the first request once every route is added
+type routeSet struct {
+	once  sync.Once
+	paths map[string]bool
+}
+
+func (s *routeSet) has(e *echo.Echo, path string) bool {
+	s.once.Do(func() {
+		s.paths = map[string]bool{}
+		for _, r := range e.Routes() {
+			s.paths[r.Path] = true
+		}
+	})
+	return s.paths[path]
+}
+
+// Metrics will record the latency of every request, by its method, route and
+// status. It answers the errors of the next handlers itself, to record the
+// status they get.
+func (m *GoMiddleware) Metrics(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if m.HTTPMetrics == nil {
+			return next(c)
+		}
+
+		start := time.Now()
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		route := c.Path()
+		if !m.routes.has(c.Echo(), route) {
+			route = routeUnmatched
+		}
+		m.HTTPMetrics.HTTPDuration.
+			WithLabelValues(c.Request().Method, route, strconv.Itoa(c.Response().Status)).
+			Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
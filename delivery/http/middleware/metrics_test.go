@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/lib/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := middleware.InitMiddleware()
+	var err error
+	m.HTTPMetrics, err = metrics.New(reg)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Use(m.Metrics)
+	e.GET("/articles/:id", func(c echo.Context) error {
+		if c.Param("id") == "7" {
+			return domain.ErrNotFound
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, path := range []string{"/articles/1", "/articles/2", "/articles/7", "/nope", "/other"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(echo.GET, path, nil))
+	}
+
+	count := func(route, status string) uint64 {
+		families, err := reg.Gather()
+		require.NoError(t, err)
+		for _, f := range families {
+			for _, metric := range f.GetMetric() {
+				labels := map[string]string{}
+				for _, l := range metric.GetLabel() {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if f.GetName() == "http_request_duration_seconds" && labels["route"] == route && labels["status"] == status {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+		return 0
+	}
+	assert.Equal(t, uint64(2), count("/articles/:id", "200"))
+	assert.Equal(t, uint64(1), count("/articles/:id", "404"))
+	// the unknown paths do not make up a label value each
+	assert.Equal(t, uint64(2), count("unmatched", "404"))
+}
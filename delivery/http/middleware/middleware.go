@@ -1,18 +1,58 @@
 package middleware
 
-import "github.com/labstack/echo"
+import (
+	"sync/atomic"
+
+	"github.com/tolbier/go-clean-arch/domain/usecases/apikey"
+	"github.com/tolbier/go-clean-arch/lib/metrics"
+	"github.com/tolbier/go-clean-arch/lib/ratelimit"
+)
 
 // GoMiddleware represent the data-struct for middleware
 type GoMiddleware struct {
 	// another stuff , may be needed by middleware
+
+	// JWTKeys verifies the bearer tokens, none are accepted when it is nil
+	JWTKeys *JWTKeys
+	// APIKeys authenticates the API keys, none are accepted when it is nil
+	APIKeys apikey.Usecase
+	// RateLimiter keeps the quotas of the clients, none is enforced when it is nil
+	RateLimiter ratelimit.Limiter
+	// UnlimitedPaths lists the routes never rate limited, by their path: the
+	// probes of the orchestrator and the scraping of the metrics come from a
+	// few addresses and must not be refused along with their other requests
+	UnlimitedPaths []string
+	// HTTPMetrics records the latency of the requests, none is recorded when it is nil
+	HTTPMetrics *metrics.Metrics
+
+	routes routeSet
+	// cors holds the *CORSPolicy applied and rateLimits the RateLimits enforced,
+	// they may be replaced while the requests are served
+	cors       atomic.Value
+	rateLimits atomic.Value
+}
+
+// SetCORSPolicy will apply p to the requests received from now on, any origin
+// is allowed without credentials when it is nil
+func (m *GoMiddleware) SetCORSPolicy(p *CORSPolicy) {
+	m.cors.Store(p)
 }
 
-// CORS will handle the CORS middleware
-func (m *GoMiddleware) CORS(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		c.Response().Header().Set("Access-Control-Allow-Origin", "*")
-		return next(c)
+func (m *GoMiddleware) corsPolicy() *CORSPolicy {
+	if p, _ := m.cors.Load().(*CORSPolicy); p != nil {
+		return p
 	}
+	return defaultCORSPolicy
+}
+
+// SetRateLimits will enforce l on the requests received from now on
+func (m *GoMiddleware) SetRateLimits(l RateLimits) {
+	m.rateLimits.Store(l)
+}
+
+func (m *GoMiddleware) limits() RateLimits {
+	l, _ := m.rateLimits.Load().(RateLimits)
+	return l
 }
 
 // InitMiddleware initialize the middleware
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/ratelimit"
+)
+
+// The headers describing the quota of the client, after the IETF draft
+// "RateLimit Header Fields for HTTP"
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// RateLimits represent the quotas of the clients
+type RateLimits struct {
+	// Default is the quota of the routes missing from Routes, shared by all of them
+	Default ratelimit.Limit
+	// Routes holds the quotas of some routes by their method and path, as
+	// "GET /articles/:id", each of them has its own bucket
+	Routes map[string]ratelimit.Limit
+	// PreAuth is the quota of each client IP over every route, taken before the
+	// request is authenticated
+	PreAuth ratelimit.Limit
+	// TrustProxy tells the client IP is taken from the X-Forwarded-For and
+	// X-Real-IP headers, set it only behind a proxy setting them
+	TrustProxy bool
+}
+
+// RateLimit will refuse the requests of the clients out of their quota, with 429
+// and the time to wait in Retry-After. The clients are told by the principal
+// the request authenticates, an API key or a subject, or else by their IP.
+// Every response tells the client where its quota stands.
+func (m *GoMiddleware) RateLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if m.RateLimiter == nil || m.unlimited(c) {
+			return next(c)
+		}
+		limits := m.limits()
+		route := c.Request().Method + " " + c.Path()
+		limit, ok := limits.Routes[route]
+		if !ok {
+			limit, route = limits.Default, "*"
+		}
+		if limit.Unlimited() {
+			return next(c)
+		}
+
+		if err := m.take(c, limits.client(c)+" "+route, limit); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// RateLimitIP will refuse the requests of the client IPs out of their PreAuth
+// quota, as RateLimit does. It runs before JWT and APIKey for the requests
+// carrying invalid credentials to be limited too, RateLimit then applies the
+// quota of the authenticated client.
+func (m *GoMiddleware) RateLimitIP(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if m.RateLimiter == nil || m.unlimited(c) {
+			return next(c)
+		}
+		limits := m.limits()
+		if limits.PreAuth.Unlimited() {
+			return next(c)
+		}
+
+		if err := m.take(c, limits.ip(c)+" pre-auth", limits.PreAuth); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// unlimited will tell whether the route of the request is one of the UnlimitedPaths
+func (m *GoMiddleware) unlimited(c echo.Context) bool {
+	for _, path := range m.UnlimitedPaths {
+		if c.Path() == path {
+			return true
+		}
+	}
+	return false
+}
+
+// take will take a token from the bucket at key for the request, and tell the
+// client where its quota stands
+func (m *GoMiddleware) take(c echo.Context, key string, limit ratelimit.Limit) error {
+	res, err := m.RateLimiter.Allow(c.Request().Context(), key, limit)
+	if err != nil {
+		// an unavailable limiter should not take the service down with it
+		logger.FromContext(c.Request().Context()).Error(err)
+		return nil
+	}
+
+	h := c.Response().Header()
+	h.Set(HeaderRateLimitLimit, strconv.Itoa(limit.Burst))
+	h.Set(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+	h.Set(HeaderRateLimitReset, ceilSeconds(res.Reset))
+	if !res.Allowed {
+		h.Set(HeaderRetryAfter, ceilSeconds(res.RetryAfter))
+		return domain.ErrTooManyRequests
+	}
+	return nil
+}
+
+// client will return the key of the client sending the request
+func (r RateLimits) client(c echo.Context) string {
+	if p, ok := domain.PrincipalFromContext(c.Request().Context()); ok {
+		return "sub:" + p.Subject
+	}
+	return r.ip(c)
+}
+
+// ip will return the key of the IP the request comes from
+func (r RateLimits) ip(c echo.Context) string {
+	if r.TrustProxy {
+		return "ip:" + c.RealIP()
+	}
+	ip, _, err := net.SplitHostPort(c.Request().RemoteAddr)
+	if err != nil {
+		ip = c.Request().RemoteAddr
+	}
+	return "ip:" + ip
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,171 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/lib/ratelimit"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimit(t *testing.T) {
+	m := middleware.InitMiddleware()
+	m.RateLimiter = ratelimit.NewMemory()
+	limits := middleware.RateLimits{
+		Default: ratelimit.Limit{Rate: 1, Burst: 2},
+		Routes:  map[string]ratelimit.Limit{"GET /articles": {Rate: 1, Burst: 1}},
+	}
+	m.SetRateLimits(limits)
+
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Use(m.RateLimit)
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/articles", ok)
+	e.GET("/articles/:id", ok)
+	e.GET("/authors/:id", ok)
+
+	serve := func(path, remoteAddr string, p *domain.Principal) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(echo.GET, path, nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.9")
+		if p != nil {
+			req = req.WithContext(domain.WithPrincipal(req.Context(), *p))
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("per-route", func(t *testing.T) {
+		rec := serve("/articles", "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRateLimitLimit))
+		assert.Equal(t, "0", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+		assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRateLimitReset))
+
+		rec = serve("/articles", "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRetryAfter))
+		assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		// the routes without a quota of their own share the default one
+		assert.Equal(t, http.StatusOK, serve("/articles/1", "10.0.0.1:1234", nil).Code)
+		assert.Equal(t, http.StatusOK, serve("/authors/1", "10.0.0.1:1234", nil).Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve("/articles/2", "10.0.0.1:1234", nil).Code)
+	})
+
+	t.Run("per-client", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/articles", "10.0.0.2:1234", nil).Code)
+
+		p := &domain.Principal{Subject: "api-key:0001"}
+		assert.Equal(t, http.StatusOK, serve("/articles", "10.0.0.1:1234", p).Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve("/articles", "10.0.0.2:1234", p).Code)
+	})
+
+	t.Run("trust-proxy", func(t *testing.T) {
+		trusting := limits
+		trusting.TrustProxy = true
+		m.SetRateLimits(trusting)
+		defer m.SetRateLimits(limits)
+
+		assert.Equal(t, http.StatusOK, serve("/articles", "10.0.0.3:1234", nil).Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve("/articles", "10.0.0.4:1234", nil).Code)
+	})
+
+	t.Run("limiter-failed", func(t *testing.T) {
+		m.RateLimiter = failingLimiter{}
+		defer func() { m.RateLimiter = ratelimit.NewMemory() }()
+
+		assert.Equal(t, http.StatusOK, serve("/articles", "10.0.0.1:1234", nil).Code)
+	})
+}
+
+func TestRateLimitIP(t *testing.T) {
+	m := middleware.InitMiddleware()
+	m.RateLimiter = ratelimit.NewMemory()
+	m.SetRateLimits(middleware.RateLimits{PreAuth: ratelimit.Limit{Rate: 1, Burst: 2}})
+
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Use(m.RateLimitIP)
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		// stands for JWT and APIKey refusing the credentials
+		return func(c echo.Context) error {
+			return domain.ErrUnauthorized
+		}
+	})
+	e.GET("/articles", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(echo.GET, "/articles", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(middleware.HeaderAPIKey, "0001.wrong")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.1:1234").Code)
+	rec := serve("10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "the failed attempts take from the quota of the IP")
+	assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRetryAfter))
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.2:1234").Code)
+}
+
+func TestRateLimitUnlimitedPaths(t *testing.T) {
+	m := middleware.InitMiddleware()
+	m.RateLimiter = ratelimit.NewMemory()
+	m.SetRateLimits(middleware.RateLimits{
+		Default: ratelimit.Limit{Rate: 1, Burst: 1},
+		PreAuth: ratelimit.Limit{Rate: 1, Burst: 1},
+	})
+	m.UnlimitedPaths = []string{"/readyz"}
+
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Use(m.RateLimitIP)
+	e.Use(m.RateLimit)
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/readyz", ok)
+	e.GET("/articles", ok)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(echo.GET, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/articles").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve("/articles").Code)
+	for i := 0; i < 3; i++ {
+		rec := serve("/readyz")
+		assert.Equal(t, http.StatusOK, rec.Code, "the probes are not limited along with the other requests of the IP")
+		assert.Empty(t, rec.Header().Get(middleware.HeaderRateLimitLimit))
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+// maxRequestIDLength is the length of the longest request id accepted from the clients
+const maxRequestIDLength = 128
+
+// RequestID will tag the request with the id in its X-Request-ID header, or a
+// new one when it has none or an invalid one, and answer it in the same header.
+// The request context carries a logger whose entries have the id in their
+// request_id field.
+func (m *GoMiddleware) RequestID(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		id := req.Header.Get(echo.HeaderXRequestID)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+		l := logrus.WithField("request_id", id)
+		c.SetRequest(req.WithContext(logger.WithLogger(req.Context(), l)))
+		return next(c)
+	}
+}
+
+// validRequestID will tell whether id is safe to log and answer back: not
+// too long, and only made of printable ASCII characters
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// AccessLog will log every request once it is answered, with its method, path,
+// status, latency and the size of the response. It answers the errors of the
+// next handlers itself, to log the status they get.
+func (m *GoMiddleware) AccessLog(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		req, res := c.Request(), c.Response()
+		logger.FromContext(req.Context()).WithFields(logrus.Fields{
+			"method":     req.Method,
+			"path":       req.URL.Path,
+			"route":      c.Path(),
+			"status":     res.Status,
+			"latency_ms": float64(time.Since(start)) / float64(time.Millisecond),
+			"bytes_in":   req.ContentLength,
+			"bytes_out":  res.Size,
+			"remote_ip":  c.RealIP(),
+		}).Info("request")
+		return nil
+	}
+}
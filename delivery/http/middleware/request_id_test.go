@@ -0,0 +1,107 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+func TestRequestID(t *testing.T) {
+	m := middleware.InitMiddleware()
+	serve := func(id string) (res string, logged string) {
+		var buf bytes.Buffer
+		out := logrus.StandardLogger().Out
+		logrus.SetOutput(&buf)
+		defer logrus.SetOutput(out)
+
+		e := echo.New()
+		req := httptest.NewRequest(echo.GET, "/articles", nil)
+		if id != "" {
+			req.Header.Set(echo.HeaderXRequestID, id)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := m.RequestID(func(c echo.Context) error {
+			logger.FromContext(c.Request().Context()).Error("connection refused")
+			return c.NoContent(http.StatusOK)
+		})(c)
+		require.NoError(t, err)
+		return rec.Header().Get(echo.HeaderXRequestID), buf.String()
+	}
+
+	t.Run("given", func(t *testing.T) {
+		id, logged := serve("abc-123")
+		assert.Equal(t, "abc-123", id)
+		assert.Contains(t, logged, "request_id=abc-123")
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		id, logged := serve("")
+		assert.Len(t, id, 32)
+		assert.Contains(t, logged, "request_id="+id)
+
+		other, _ := serve("")
+		assert.NotEqual(t, id, other)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, given := range []string{"abc 123", "abc\x00", strings.Repeat("a", 129)} {
+			id, _ := serve(given)
+			assert.NotEqual(t, given, id)
+			assert.Len(t, id, 32)
+		}
+	})
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.Out = &buf
+	l.Formatter = &logrus.JSONFormatter{}
+
+	m := middleware.InitMiddleware()
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(logger.WithLogger(req.Context(), l.WithField("request_id", "abc"))))
+			return next(c)
+		}
+	})
+	e.Use(m.AccessLog)
+	e.GET("/articles/:id", func(c echo.Context) error {
+		return domain.ErrNotFound
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(echo.GET, "/articles/7", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// the last entry is the access log, the error was logged before
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &entry))
+	assert.Equal(t, "request", entry["msg"])
+	assert.Equal(t, "abc", entry["request_id"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/articles/7", entry["path"])
+	assert.Equal(t, "/articles/:id", entry["route"])
+	assert.Equal(t, float64(http.StatusNotFound), entry["status"])
+	assert.Equal(t, float64(rec.Body.Len()), entry["bytes_out"])
+	assert.Contains(t, entry, "latency_ms")
+}
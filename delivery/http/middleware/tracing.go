@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/key"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+	"google.golang.org/grpc/codes"
+
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// Tracing will record a span for every request, continuing the trace of its
+// traceparent header if any. The spans of the usecases and the repositories
+// called for the request are its children. It answers the errors of the next
+// handlers itself, to record the status they get, and adds the trace id to the
+// entries of the request logger.
+func (m *GoMiddleware) Tracing(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		ctx := propagation.ExtractHTTP(req.Context(), global.Propagators(), req.Header)
+
+		route := c.Path()
+		if !m.routes.has(c.Echo(), route) {
+			route = routeUnmatched
+		}
+		ctx, span := global.Tracer(tracing.TracerName).Start(ctx, "HTTP "+req.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				key.String("http.method", req.Method),
+				key.String("http.route", route),
+				key.String("http.target", req.URL.RequestURI()),
+				key.String("http.user_agent", req.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = logger.WithLogger(ctx, logger.FromContext(ctx).WithField("trace_id", sc.TraceID.String()))
+		}
+		c.SetRequest(req.WithContext(ctx))
+
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		status := c.Response().Status
+		span.SetAttributes(key.Int("http.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Internal, "")
+			span.SetAttributes(key.Bool("error", true))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/middleware"
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	var buf bytes.Buffer
+	shutdown, err := tracing.Setup(tracing.Config{Exporter: "stdout", SampleRatio: 1, ServiceName: "test", Writer: &buf})
+	require.NoError(t, err)
+
+	var traceID interface{}
+	m := middleware.InitMiddleware()
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Use(m.RequestID)
+	e.Use(m.Tracing)
+	e.GET("/articles/:id", func(c echo.Context) error {
+		if c.Param("id") == "7" {
+			return errors.New("boom")
+		}
+		traceID = logger.FromContext(c.Request().Context()).Data["trace_id"]
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(echo.GET, "/articles/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(echo.GET, "/articles/7", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	shutdown()
+
+	type span struct {
+		SpanContext struct {
+			TraceID string
+		}
+		ParentSpanID    string
+		Name            string
+		StatusCode      codes.Code
+		HasRemoteParent bool
+		Attributes      []struct {
+			Key   string
+			Value struct {
+				Value interface{}
+			}
+		}
+	}
+	var spans []span
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var s span
+		require.NoError(t, dec.Decode(&s))
+		spans = append(spans, s)
+	}
+	require.Len(t, spans, 2)
+	status := func(s span) interface{} {
+		for _, a := range s.Attributes {
+			if a.Key == "http.status_code" {
+				return a.Value.Value
+			}
+		}
+		return nil
+	}
+
+	t.Run("continues-the-trace", func(t *testing.T) {
+		s := spans[0]
+		assert.Equal(t, "HTTP GET /articles/:id", s.Name)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", s.SpanContext.TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", s.ParentSpanID)
+		assert.True(t, s.HasRemoteParent)
+		assert.Equal(t, codes.OK, s.StatusCode)
+		assert.Equal(t, float64(http.StatusOK), status(s))
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+	})
+	t.Run("server-error", func(t *testing.T) {
+		s := spans[1]
+		assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", s.SpanContext.TraceID)
+		assert.False(t, s.HasRemoteParent)
+		assert.Equal(t, codes.Internal, s.StatusCode)
+		assert.Equal(t, float64(http.StatusInternalServerError), status(s))
+	})
+}
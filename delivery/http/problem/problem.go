@@ -0,0 +1,123 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+// MIMEApplicationProblemJSON is the media type of the problem details (RFC 7807) documents
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem represent the problem details (RFC 7807) of an error response
+type Problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	Code     domain.Code            `json:"code,omitempty"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+var statusCodes = map[domain.Code]int{
+	domain.CodeInternal:           http.StatusInternalServerError,
+	domain.CodeNotFound:           http.StatusNotFound,
+	domain.CodeConflict:           http.StatusConflict,
+	domain.CodeBadParamInput:      http.StatusBadRequest,
+	domain.CodePreconditionFailed: http.StatusPreconditionFailed,
+	domain.CodeUnauthorized:       http.StatusUnauthorized,
+	domain.CodeForbidden:          http.StatusForbidden,
+	domain.CodeTooManyRequests:    http.StatusTooManyRequests,
+}
+
+// StatusCode will return the status of the response reporting err
+func StatusCode(err error) int {
+	var de *domain.Error
+	if errors.As(err, &de) {
+		if status, ok := statusCodes[de.Code]; ok {
+			return status
+		}
+		return http.StatusInternalServerError
+	}
+	if he, ok := err.(*echo.HTTPError); ok {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
+
+// New will build the problem details reporting err. The internal server
+// errors are described generically, their cause is only logged.
+func New(err error) Problem {
+	status := StatusCode(err)
+	p := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	var de *domain.Error
+	switch {
+	case status == http.StatusInternalServerError:
+		p.Code = domain.CodeInternal
+		p.Detail = domain.ErrInternalServerError.Message
+	case errors.As(err, &de):
+		p.Code = de.Code
+		p.Detail = de.Message
+		p.Details = de.Details
+	default:
+		if he, ok := err.(*echo.HTTPError); ok {
+			p.Detail = fmt.Sprint(he.Message)
+		}
+	}
+	return p
+}
+
+// UnprocessableEntity will return the error reporting a request body that cannot be read or decoded
+func UnprocessableEntity(err error) *echo.HTTPError {
+	if he, ok := err.(*echo.HTTPError); ok {
+		return &echo.HTTPError{Code: http.StatusUnprocessableEntity, Message: he.Message, Internal: he.Internal}
+	}
+	return &echo.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error(), Internal: err}
+}
+
+// HTTPErrorHandler will answer the requests failed with err with its problem details
+func HTTPErrorHandler(err error, c echo.Context) {
+	p := New(err)
+	p.Instance = c.Request().URL.Path
+	l := logger.FromContext(c.Request().Context())
+	if p.Status >= http.StatusInternalServerError {
+		l.Error(err)
+	} else {
+		l.Debug(err)
+	}
+
+	if c.Response().Committed {
+		return
+	}
+	if p.Status == http.StatusUnauthorized {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	}
+	if c.Request().Method == echo.HEAD {
+		err = c.NoContent(p.Status)
+	} else {
+		err = write(c, p)
+	}
+	if err != nil {
+		l.Error(err)
+	}
+}
+
+func write(c echo.Context, p Problem) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return c.Blob(p.Status, MIMEApplicationProblemJSON, body)
+}
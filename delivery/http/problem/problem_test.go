@@ -0,0 +1,109 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/problem"
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{domain.ErrNotFound, http.StatusNotFound},
+		{fmt.Errorf("fetching article: %w", domain.ErrNotFound), http.StatusNotFound},
+		{domain.ErrConflict, http.StatusConflict},
+		{domain.ErrAuthorHasArticles, http.StatusConflict},
+		{domain.ErrBadParamInput.WithMessage("title is required"), http.StatusBadRequest},
+		{domain.ErrPreconditionFailed, http.StatusPreconditionFailed},
+		{domain.ErrUnauthorized, http.StatusUnauthorized},
+		{domain.ErrForbidden, http.StatusForbidden},
+		{domain.ErrTooManyRequests, http.StatusTooManyRequests},
+		{domain.ErrInternalServerError, http.StatusInternalServerError},
+		{echo.NewHTTPError(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType},
+		{errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.status, problem.StatusCode(tt.err), tt.err.Error())
+	}
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	t.Run("domain-error", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(echo.GET, "/articles?order=newest", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := domain.ErrBadParamInput.WithDetails(map[string]interface{}{"param": "order"})
+		problem.HTTPErrorHandler(fmt.Errorf("listing articles: %w", err), c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		var res problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, problem.Problem{
+			Type:     "about:blank",
+			Title:    "Bad Request",
+			Status:   http.StatusBadRequest,
+			Detail:   domain.ErrBadParamInput.Message,
+			Instance: "/articles",
+			Code:     domain.CodeBadParamInput,
+			Details:  map[string]interface{}{"param": "order"},
+		}, res)
+	})
+
+	t.Run("internal-error", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(echo.GET, "/articles", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		problem.HTTPErrorHandler(errors.New("dial tcp 10.0.0.1:3306: connection refused"), c)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		var res problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, domain.CodeInternal, res.Code)
+		assert.Equal(t, domain.ErrInternalServerError.Message, res.Detail)
+		assert.NotContains(t, rec.Body.String(), "10.0.0.1")
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(echo.DELETE, "/articles/1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		problem.HTTPErrorHandler(domain.ErrUnauthorized, c)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+
+	t.Run("http-error", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(echo.POST, "/articles", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		problem.HTTPErrorHandler(problem.UnprocessableEntity(echo.NewHTTPError(http.StatusBadRequest, "Syntax error")), c)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		var res problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "Syntax error", res.Detail)
+		assert.Empty(t, res.Code)
+	})
+}
@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// idMessages are the Indonesian messages of the rules, where {0} is the name of
+// the field and {1} the parameter of the rule. The rules comparing a length have
+// a message for the strings, the lists and the numbers, their key is suffixed
+// with -string, -items and -number.
+var idMessages = map[string]string{
+	"required":     "{0} wajib diisi",
+	"len-string":   "panjang {0} harus {1} karakter",
+	"len-items":    "{0} harus berisi {1} item",
+	"len-number":   "{0} harus sama dengan {1}",
+	"min-string":   "panjang {0} minimal {1} karakter",
+	"min-items":    "{0} harus berisi minimal {1} item",
+	"min-number":   "{0} harus {1} atau lebih besar",
+	"max-string":   "panjang {0} maksimal {1} karakter",
+	"max-items":    "{0} harus berisi maksimal {1} item",
+	"max-number":   "{0} harus {1} atau lebih kecil",
+	"eq":           "{0} tidak sama dengan {1}",
+	"ne":           "{0} tidak boleh sama dengan {1}",
+	"lt-string":    "panjang {0} harus kurang dari {1} karakter",
+	"lt-items":     "{0} harus berisi kurang dari {1} item",
+	"lt-number":    "{0} harus kurang dari {1}",
+	"lte-string":   "panjang {0} maksimal {1} karakter",
+	"lte-items":    "{0} harus berisi maksimal {1} item",
+	"lte-number":   "{0} harus {1} atau lebih kecil",
+	"gt-string":    "panjang {0} harus lebih dari {1} karakter",
+	"gt-items":     "{0} harus berisi lebih dari {1} item",
+	"gt-number":    "{0} harus lebih besar dari {1}",
+	"gte-string":   "panjang {0} minimal {1} karakter",
+	"gte-items":    "{0} harus berisi minimal {1} item",
+	"gte-number":   "{0} harus {1} atau lebih besar",
+	"alpha":        "{0} hanya boleh berisi huruf",
+	"alphanum":     "{0} hanya boleh berisi huruf dan angka",
+	"numeric":      "{0} harus berupa angka",
+	"number":       "{0} harus berupa angka",
+	"email":        "{0} harus berupa alamat email yang valid",
+	"url":          "{0} harus berupa URL yang valid",
+	"uri":          "{0} harus berupa URI yang valid",
+	"uuid":         "{0} harus berupa UUID yang valid",
+	"contains":     "{0} harus berisi teks '{1}'",
+	"excludes":     "{0} tidak boleh berisi teks '{1}'",
+	"eqfield":      "{0} harus sama dengan {1}",
+	"nefield":      "{0} tidak boleh sama dengan {1}",
+	"gtfield":      "{0} harus lebih besar dari {1}",
+	"gtefield":     "{0} harus lebih besar dari atau sama dengan {1}",
+	"ltfield":      "{0} harus kurang dari {1}",
+	"ltefield":     "{0} harus kurang dari atau sama dengan {1}",
+	"datetime":     "{0} tidak sesuai dengan format {1}",
+	"oneof":        "{0} harus berupa salah satu dari [{1}]",
+	"hexadecimal":  "{0} harus berupa heksadesimal yang valid",
+	"base64":       "{0} harus berupa string Base64 yang valid",
+	"ip":           "{0} harus berupa alamat IP yang valid",
+	"printascii":   "{0} hanya boleh berisi karakter ASCII yang dapat dicetak",
+	"containsany":  "{0} harus berisi setidaknya salah satu karakter '{1}'",
+	"excludesall":  "{0} tidak boleh berisi karakter '{1}'",
+	"excludesrune": "{0} tidak boleh berisi '{1}'",
+}
+
+// sized are the rules comparing the length of the strings and lists
+var sized = map[string]bool{"len": true, "min": true, "max": true, "lt": true, "lte": true, "gt": true, "gte": true}
+
+func registerIndonesianTranslations(v *validator.Validate, trans ut.Translator) error {
+	for key, message := range idMessages {
+		if err := trans.Add(key, message, false); err != nil {
+			return err
+		}
+	}
+
+	tags := map[string]bool{}
+	for key := range idMessages {
+		tags[strings.SplitN(key, "-", 2)[0]] = true
+	}
+
+	for tag := range tags {
+		err := v.RegisterTranslation(tag, trans, func(ut.Translator) error {
+			// the messages are already added above
+			return nil
+		}, translateID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func translateID(trans ut.Translator, fe validator.FieldError) string {
+	key := fe.Tag()
+	if sized[key] {
+		kind := fe.Kind()
+		if kind == reflect.Ptr {
+			kind = fe.Type().Elem().Kind()
+		}
+		switch kind {
+		case reflect.String:
+			key += "-string"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			key += "-items"
+		default:
+			key += "-number"
+		}
+	}
+
+	t, err := trans.T(key, fe.Field(), fe.Param())
+	if err != nil {
+		return ""
+	}
+	return t
+}
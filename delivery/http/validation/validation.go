@@ -0,0 +1,219 @@
+package validation
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/labstack/echo"
+	validator "gopkg.in/go-playground/validator.v9"
+	enTranslations "gopkg.in/go-playground/validator.v9/translations/en"
+
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+// FieldError represent a field of the request body failing one of its validation rules
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// HeaderAcceptLanguage is the request header listing the languages the client prefers
+const HeaderAcceptLanguage = "Accept-Language"
+
+// keyInvalidRequest is the translation key of the detail of the validation failures
+const keyInvalidRequest = "invalid_request"
+
+// keyInvalidField is the translation key of the message of the rules without translation
+const keyInvalidField = "invalid_field"
+
+var (
+	mu       sync.RWMutex
+	validate = validator.New()
+	uni      = ut.New(en.New(), en.New(), id.New())
+)
+
+func init() {
+	validate.RegisterTagNameFunc(jsonName)
+
+	enTrans, _ := uni.GetTranslator("en")
+	if err := enTranslations.RegisterDefaultTranslations(validate, enTrans); err != nil {
+		panic(err)
+	}
+	enTrans.Add(keyInvalidRequest, "The request has invalid fields", false)
+	enTrans.Add(keyInvalidField, "{0} is not valid", false)
+
+	idTrans, _ := uni.GetTranslator("id")
+	if err := registerIndonesianTranslations(validate, idTrans); err != nil {
+		panic(err)
+	}
+	idTrans.Add(keyInvalidRequest, "Permintaan memiliki isian yang tidak valid", false)
+	idTrans.Add(keyInvalidField, "{0} tidak valid", false)
+}
+
+// jsonName will name the fields after their JSON name, as the clients know them
+func jsonName(f reflect.StructField) string {
+	name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// RegisterValidation will add the rule tag, checked by fn, to the rules shared
+// by every entity. messages holds its message by language, where {0} is the
+// name of the field and {1} the parameter of the rule.
+func RegisterValidation(tag string, fn validator.Func, messages map[string]string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := validate.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+	for lang, message := range messages {
+		trans, found := uni.GetTranslator(lang)
+		if !found {
+			continue
+		}
+		message := message
+		err := validate.RegisterTranslation(tag, trans, func(trans ut.Translator) error {
+			return trans.Add(tag, message, true)
+		}, func(trans ut.Translator, fe validator.FieldError) string {
+			t, _ := trans.T(tag, fe.Field(), fe.Param())
+			return t
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Struct will validate s against the rules of its validate tags, the failures
+// are reported as a domain.ErrBadParamInput listing them in its "errors"
+// details, translated in the first language of acceptLanguage we support
+func Struct(s interface{}, acceptLanguage string) error {
+	mu.RLock()
+	err := validate.Struct(s)
+	mu.RUnlock()
+	if err == nil {
+		return nil
+	}
+
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return domain.ErrBadParamInput.Wrap(err)
+	}
+
+	trans := Translator(acceptLanguage)
+	fields := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fields = append(fields, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: message(trans, fe),
+		})
+	}
+
+	detail, _ := trans.T(keyInvalidRequest)
+	return domain.ErrBadParamInput.Wrap(err).WithMessage(detail).WithDetails(map[string]interface{}{
+		"errors": fields,
+	})
+}
+
+// Validate will validate s as Struct does, in the language asked for by the request
+func Validate(c echo.Context, s interface{}) error {
+	return Struct(s, c.Request().Header.Get(HeaderAcceptLanguage))
+}
+
+// PageSize will read the num query param of the request, the number of items of
+// the page asked for. It is zero when the param is missing, for the usecase to
+// use its default, and refused with domain.ErrBadParamInput unless it is a
+// positive number.
+func PageSize(c echo.Context) (int64, error) {
+	numS := c.QueryParam("num")
+	if numS == "" {
+		return 0, nil
+	}
+	num, err := strconv.ParseInt(numS, 10, 64)
+	if err != nil || num < 1 {
+		return 0, domain.ErrBadParamInput.WithDetails(map[string]interface{}{"param": "num"})
+	}
+	return num, nil
+}
+
+// fieldPath will return the path of the field in the request body, such as author.name
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
+
+func message(trans ut.Translator, fe validator.FieldError) string {
+	if msg := fe.Translate(trans); msg != "" && msg != fe.(error).Error() {
+		return msg
+	}
+	msg, _ := trans.T(keyInvalidField, fe.Field())
+	return msg
+}
+
+// Translator will return the translator of the first language of the given
+// Accept-Language header we support, English by default
+func Translator(acceptLanguage string) ut.Translator {
+	trans, _ := uni.FindTranslator(languages(acceptLanguage)...)
+	return trans
+}
+
+// languages will list the languages of an Accept-Language header by decreasing
+// preference, a regional variant such as id-ID is followed by its base language
+func languages(header string) []string {
+	type language struct {
+		tag string
+		q   float64
+	}
+
+	var langs []language
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			langs = append(langs, language{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].q > langs[j].q
+	})
+
+	res := make([]string, 0, 2*len(langs))
+	for _, l := range langs {
+		tag := strings.ToLower(strings.Replace(l.tag, "-", "_", -1))
+		res = append(res, tag)
+		if i := strings.Index(tag, "_"); i > 0 {
+			res = append(res, tag[:i])
+		}
+	}
+	return res
+}
@@ -0,0 +1,96 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"github.com/tolbier/go-clean-arch/delivery/http/validation"
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+type author struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type post struct {
+	Title  string   `json:"title" validate:"required,max=5"`
+	Tags   []string `json:"tags" validate:"min=1"`
+	Slug   string   `json:"slug" validate:"omitempty,slug"`
+	Author author   `json:"author"`
+}
+
+func fieldErrors(t *testing.T, err error) []validation.FieldError {
+	var de *domain.Error
+	require.True(t, errors.As(err, &de))
+	assert.True(t, errors.Is(err, domain.ErrBadParamInput))
+	return de.Details["errors"].([]validation.FieldError)
+}
+
+func TestStruct(t *testing.T) {
+	require.NoError(t, validation.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		for _, r := range fl.Field().String() {
+			if (r < 'a' || r > 'z') && r != '-' {
+				return false
+			}
+		}
+		return true
+	}, map[string]string{
+		"en": "{0} must only contain lowercase letters and dashes",
+		"id": "{0} hanya boleh berisi huruf kecil dan tanda hubung",
+	}))
+
+	p := post{Title: "Makan Ayam", Slug: "Makan Ayam"}
+
+	t.Run("success", func(t *testing.T) {
+		valid := post{Title: "Makan", Tags: []string{"food"}, Slug: "makan", Author: author{Name: "Iman"}}
+		assert.NoError(t, validation.Struct(&valid, ""))
+	})
+
+	t.Run("en", func(t *testing.T) {
+		err := validation.Struct(&p, "")
+		assert.Equal(t, "The request has invalid fields", err.(*domain.Error).Message)
+		assert.Equal(t, []validation.FieldError{
+			{Field: "title", Rule: "max", Message: "title must be a maximum of 5 characters in length"},
+			{Field: "tags", Rule: "min", Message: "tags must contain at least 1 item"},
+			{Field: "slug", Rule: "slug", Message: "slug must only contain lowercase letters and dashes"},
+			{Field: "author.name", Rule: "required", Message: "name is a required field"},
+		}, fieldErrors(t, err))
+	})
+
+	t.Run("id", func(t *testing.T) {
+		err := validation.Struct(&p, "fr-CH, id-ID;q=0.9, en;q=0.8")
+		assert.Equal(t, "Permintaan memiliki isian yang tidak valid", err.(*domain.Error).Message)
+		assert.Equal(t, []validation.FieldError{
+			{Field: "title", Rule: "max", Message: "panjang title maksimal 5 karakter"},
+			{Field: "tags", Rule: "min", Message: "tags harus berisi minimal 1 item"},
+			{Field: "slug", Rule: "slug", Message: "slug hanya boleh berisi huruf kecil dan tanda hubung"},
+			{Field: "author.name", Rule: "required", Message: "name wajib diisi"},
+		}, fieldErrors(t, err))
+	})
+
+	t.Run("preference", func(t *testing.T) {
+		err := validation.Struct(&author{}, "id;q=0.5, en")
+		assert.Equal(t, "name is a required field", fieldErrors(t, err)[0].Message)
+
+		err = validation.Struct(&author{}, "en;q=0, id")
+		assert.Equal(t, "name wajib diisi", fieldErrors(t, err)[0].Message)
+	})
+
+	t.Run("untranslated-rule", func(t *testing.T) {
+		require.NoError(t, validation.RegisterValidation("even", func(fl validator.FieldLevel) bool {
+			return fl.Field().Int()%2 == 0
+		}, nil))
+		type shelf struct {
+			Slots int `json:"slots" validate:"even"`
+		}
+		err := validation.Struct(&shelf{Slots: 3}, "en")
+		assert.Equal(t, "slots is not valid", fieldErrors(t, err)[0].Message)
+
+		err = validation.Struct(&shelf{Slots: 3}, "id")
+		assert.Equal(t, "slots tidak valid", fieldErrors(t, err)[0].Message)
+	})
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+)
+
+const (
+	// ScopeArticlesRead allows reading the articles
+	ScopeArticlesRead = "articles:read"
+	// ScopeArticlesWrite allows writing the articles, as the author of the key
+	ScopeArticlesWrite = "articles:write"
+)
+
+// APIKey is the long-lived credential of a machine client. Only the hash of
+// the key is stored, the key itself is given once, when it is issued.
+type APIKey struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name" validate:"required"`
+	// Prefix is the public part of the key, identifying it
+	Prefix string `json:"prefix"`
+	// Hash is the SHA-256 of the key, hex encoded
+	Hash   string   `json:"-"`
+	Scopes []string `json:"scopes" validate:"required,dive,oneof=articles:read articles:write"`
+	// AuthorID is the author the client writes the articles as, zero for none
+	AuthorID  int64      `json:"author_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// HasScope will tell whether the key grants the given scope
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
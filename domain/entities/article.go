@@ -6,10 +6,12 @@ import (
 
 // Article ...
 type Article struct {
-	ID        int64     `json:"id"`
-	Title     string    `json:"title" validate:"required"`
-	Content   string    `json:"content" validate:"required"`
-	Author    Author    `json:"author"`
-	UpdatedAt time.Time `json:"updated_at"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         int64      `json:"id"`
+	Title      string     `json:"title" validate:"required"`
+	Content    string     `json:"content" validate:"required"`
+	Author     Author     `json:"author" validate:"-"`
+	Categories []Category `json:"categories"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Version    int64      `json:"version"`
 }
@@ -0,0 +1,11 @@
+package entities
+
+// ArticleMatch is an article found by a full-text search
+type ArticleMatch struct {
+	Article
+	// Score ranks the match, the higher the more relevant
+	Score float64 `json:"score"`
+	// Snippet is the HTML escaped part of the content around the matched
+	// terms, each of them surrounded by <mark></mark>
+	Snippet string `json:"snippet"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"time"
+)
+
+// Category ...
+type Category struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name" validate:"required"`
+	Tag       string    `json:"tag" validate:"required"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,14 +1,117 @@
 package domain
 
-import "errors"
+// Code classifies an Error, the delivery layer maps it to the status of its response
+type Code string
+
+const (
+	// CodeInternal is the code of the unexpected failures
+	CodeInternal Code = "internal"
+	// CodeNotFound is the code of the errors raised when the requested item does not exist
+	CodeNotFound Code = "not_found"
+	// CodeConflict is the code of the errors raised when the action conflicts with the stored items
+	CodeConflict Code = "conflict"
+	// CodeBadParamInput is the code of the errors raised when the request-body or params are not valid
+	CodeBadParamInput Code = "bad_param_input"
+	// CodePreconditionFailed is the code of the errors raised when the item is not in the expected version
+	CodePreconditionFailed Code = "precondition_failed"
+	// CodeUnauthorized is the code of the errors raised when the caller is not authenticated
+	CodeUnauthorized Code = "unauthorized"
+	// CodeForbidden is the code of the errors raised when the caller may not perform the action
+	CodeForbidden Code = "forbidden"
+	// CodeTooManyRequests is the code of the errors raised when the caller ran out of its quota
+	CodeTooManyRequests Code = "too_many_requests"
+)
 
 var (
 	// ErrInternalServerError will throw if any the Internal Server Error happen
-	ErrInternalServerError = errors.New("Internal Server Error")
+	ErrInternalServerError = NewError(CodeInternal, "Internal Server Error")
 	// ErrNotFound will throw if the requested item is not exists
-	ErrNotFound = errors.New("Your requested Item is not found")
+	ErrNotFound = NewError(CodeNotFound, "Your requested Item is not found")
 	// ErrConflict will throw if the current action already exists
-	ErrConflict = errors.New("Your Item already exist")
+	ErrConflict = NewError(CodeConflict, "Your Item already exist")
 	// ErrBadParamInput will throw if the given request-body or params is not valid
-	ErrBadParamInput = errors.New("Given Param is not valid")
+	ErrBadParamInput = NewError(CodeBadParamInput, "Given Param is not valid")
+	// ErrPreconditionFailed will throw if the item was modified since the version the client expected
+	ErrPreconditionFailed = NewError(CodePreconditionFailed, "Your Item has been modified, reload it and retry")
+	// ErrAuthorHasArticles will throw if the author to be deleted still owns articles
+	ErrAuthorHasArticles = NewError(CodeConflict, "Author still has articles, reassign them first")
+	// ErrUnauthorized will throw if the caller is not authenticated, or its credentials are not valid
+	ErrUnauthorized = NewError(CodeUnauthorized, "Authentication is required")
+	// ErrForbidden will throw if the authenticated caller is not allowed to perform the action
+	ErrForbidden = NewError(CodeForbidden, "You are not allowed to perform this action")
+	// ErrTooManyRequests will throw if the caller sent more requests than its quota allows
+	ErrTooManyRequests = NewError(CodeTooManyRequests, "Too many requests, retry later")
 )
+
+// Error represent an error of the domain. The errors derived from one of the
+// sentinel errors above, with Wrap, WithMessage or WithDetails, still match
+// it with errors.Is, and errors.As gives access to their code and details.
+type Error struct {
+	Code    Code
+	Message string
+	// Details holds additional information about the error, safe to show to the client
+	Details map[string]interface{}
+	// Cause is the underlying error, it is not meant to be shown to the client
+	Cause error
+
+	// kind is the sentinel error this one derives from
+	kind *Error
+}
+
+// NewError will create a new kind of error with the given code and message
+func NewError(code Code, message string) *Error {
+	e := &Error{Code: code, Message: message}
+	e.kind = e
+	return e
+}
+
+// Error will return the message of the error, followed by its cause if any
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap will return the cause of the error
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is will tell whether the error derives from the same sentinel error as target
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.kind == e.kind
+}
+
+// Wrap will return a copy of the error caused by cause
+func (e *Error) Wrap(cause error) *Error {
+	c := e.clone()
+	c.Cause = cause
+	return c
+}
+
+// WithMessage will return a copy of the error with the given message
+func (e *Error) WithMessage(message string) *Error {
+	c := e.clone()
+	c.Message = message
+	return c
+}
+
+// WithDetails will return a copy of the error with the given details added to its own
+func (e *Error) WithDetails(details map[string]interface{}) *Error {
+	c := e.clone()
+	c.Details = make(map[string]interface{}, len(e.Details)+len(details))
+	for k, v := range e.Details {
+		c.Details[k] = v
+	}
+	for k, v := range details {
+		c.Details[k] = v
+	}
+	return c
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	return &c
+}
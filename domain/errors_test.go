@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("duplicate entry")
+	err := fmt.Errorf("storing article: %w", domain.ErrConflict.Wrap(cause).WithDetails(map[string]interface{}{"field": "title"}))
+
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+	assert.True(t, errors.Is(err, cause))
+	assert.False(t, errors.Is(err, domain.ErrAuthorHasArticles))
+	assert.Equal(t, "storing article: Your Item already exist: duplicate entry", err.Error())
+
+	var de *domain.Error
+	require.True(t, errors.As(err, &de))
+	assert.Equal(t, domain.CodeConflict, de.Code)
+	assert.Equal(t, map[string]interface{}{"field": "title"}, de.Details)
+
+	// the sentinel errors are left untouched
+	assert.Nil(t, domain.ErrConflict.Cause)
+	assert.Nil(t, domain.ErrConflict.Details)
+}
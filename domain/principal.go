@@ -0,0 +1,46 @@
+package domain
+
+import "context"
+
+// Principal represent the authenticated caller of a request
+type Principal struct {
+	// Subject identifies the caller to the issuer of its credentials
+	Subject string
+	// AuthorID is the author the caller writes as, zero if it is not an author
+	AuthorID int64
+	// Admin may write the articles of every author
+	Admin bool
+	// Scopes restricts what the caller may do, nil when it is not restricted
+	Scopes []string
+}
+
+type principalKey struct{}
+
+// WithPrincipal will return a copy of ctx carrying the given principal
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext will return the principal carried by ctx, if any
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// CanWriteAs will tell whether the principal may write the items of the given author
+func (p Principal) CanWriteAs(authorID int64) bool {
+	return p.Admin || (p.AuthorID != 0 && p.AuthorID == authorID)
+}
+
+// HasScope will tell whether the principal is allowed the given scope
+func (p Principal) HasScope(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+)
+
+// APIKeyRepository represent the api key's repository contract
+type APIKeyRepository interface {
+	Fetch(ctx context.Context) ([]entities.APIKey, error)
+	GetByID(ctx context.Context, id int64) (entities.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (entities.APIKey, error)
+	Store(ctx context.Context, k *entities.APIKey) error
+	// Revoke marks the key as revoked at the given time, it returns
+	// domain.ErrNotFound if there is no such key or it is already revoked
+	Revoke(ctx context.Context, id int64, revokedAt time.Time) error
+}
@@ -7,10 +7,18 @@ import (
 
 // ArticleRepository represent the article's repository contract
 type ArticleRepository interface {
-	Fetch(ctx context.Context, cursor string, num int64) (res []Article, nextCursor string, err error)
+	// Fetch, FetchByCategory and FetchByAuthor page through the articles ordered by creation time,
+	// returning the cursors of the next and the previous pages, empty when there is none
+	Fetch(ctx context.Context, cursor string, num int64) (res []Article, nextCursor string, prevCursor string, err error)
+	FetchByCategory(ctx context.Context, tag string, cursor string, num int64) (res []Article, nextCursor string, prevCursor string,
+		err error)
+	FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) (res []Article, nextCursor string, prevCursor string,
+		err error)
 	GetByID(ctx context.Context, id int64) (Article, error)
 	GetByTitle(ctx context.Context, title string) (Article, error)
+	// Search returns the articles matching query, the most relevant first
+	Search(ctx context.Context, query string, cursor string, num int64) (res []ArticleMatch, nextCursor string, err error)
 	Update(ctx context.Context, ar *Article) error
 	Store(ctx context.Context, a *Article) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64, version int64) error
 }
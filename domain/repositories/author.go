@@ -7,5 +7,15 @@ import (
 
 // AuthorRepository represent the author's repository contract
 type AuthorRepository interface {
+	Fetch(ctx context.Context, cursor string, num int64) (res []entities.Author, nextCursor string, err error)
 	GetByID(ctx context.Context, id int64) (entities.Author, error)
+	// GetByIDs returns the authors found among ids, keyed by their id. An id
+	// without author is absent from the map, it is not an error.
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]entities.Author, error)
+	Update(ctx context.Context, a *entities.Author) error
+	Store(ctx context.Context, a *entities.Author) error
+	// Delete removes the author. When reassignTo is not zero its articles are
+	// moved to that author in the same transaction, otherwise an author owning
+	// articles is refused with domain.ErrAuthorHasArticles.
+	Delete(ctx context.Context, id int64, reassignTo int64) error
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+)
+
+// CategoryRepository represent the category's repository contract
+type CategoryRepository interface {
+	Fetch(ctx context.Context) ([]entities.Category, error)
+	GetByID(ctx context.Context, id int64) (entities.Category, error)
+	GetByTag(ctx context.Context, tag string) (entities.Category, error)
+	GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]entities.Category, error)
+	Store(ctx context.Context, c *entities.Category) error
+	Update(ctx context.Context, c *entities.Category) error
+	Delete(ctx context.Context, id int64) error
+	Attach(ctx context.Context, articleID int64, categoryID int64) error
+	Detach(ctx context.Context, articleID int64, categoryID int64) error
+}
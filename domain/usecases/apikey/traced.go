@@ -0,0 +1,48 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedUsecase records a span for each call to a Usecase
+type tracedUsecase struct {
+	next Usecase
+}
+
+// NewTracedUsecase will create an implementation of Usecase recording a span for each call to next
+func NewTracedUsecase(next Usecase) Usecase {
+	return &tracedUsecase{next: next}
+}
+
+func (u *tracedUsecase) Fetch(ctx context.Context) (res []entities.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.Fetch")
+	defer tracing.End(span, &err)
+	return u.next.Fetch(ctx)
+}
+
+func (u *tracedUsecase) Issue(ctx context.Context, k *entities.APIKey) (key string, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.Issue")
+	defer tracing.End(span, &err)
+	return u.next.Issue(ctx, k)
+}
+
+func (u *tracedUsecase) Revoke(ctx context.Context, id int64) (err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.Revoke")
+	defer tracing.End(span, &err)
+	return u.next.Revoke(ctx, id)
+}
+
+func (u *tracedUsecase) Rotate(ctx context.Context, id int64) (res entities.APIKey, key string, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.Rotate")
+	defer tracing.End(span, &err)
+	return u.next.Rotate(ctx, id)
+}
+
+func (u *tracedUsecase) Authenticate(ctx context.Context, key string) (res entities.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.Authenticate")
+	defer tracing.End(span, &err)
+	return u.next.Authenticate(ctx, key)
+}
@@ -0,0 +1,179 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+)
+
+// ErrInvalidKey is returned when an API key is unknown, revoked or malformed
+var ErrInvalidKey = domain.ErrUnauthorized.WithMessage("The API key is not valid")
+
+// Usecase represent the api key's usecases. Only the admins may list and
+// manage the keys.
+type Usecase interface {
+	Fetch(ctx context.Context) ([]entities.APIKey, error)
+	// Issue stores the key and returns its secret value, it cannot be retrieved later
+	Issue(ctx context.Context, k *entities.APIKey) (string, error)
+	Revoke(ctx context.Context, id int64) error
+	// Rotate issues a new key with the name, scopes and author of the given one,
+	// which is revoked
+	Rotate(ctx context.Context, id int64) (entities.APIKey, string, error)
+	// Authenticate returns the key matching the secret value, ErrInvalidKey if
+	// there is none or it is revoked
+	Authenticate(ctx context.Context, key string) (entities.APIKey, error)
+}
+
+type usecase struct {
+	// contextTimeout is the time.Duration the calls are given, it is accessed
+	// atomically to be replaced while they run
+	contextTimeout int64
+	apiKeyRepo     repositories.APIKeyRepository
+}
+
+// NewUsecase will create new an usecase object representation of apikey.Usecase interface
+func NewUsecase(k repositories.APIKeyRepository, timeout time.Duration) Usecase {
+	return &usecase{
+		apiKeyRepo:     k,
+		contextTimeout: int64(timeout),
+	}
+}
+
+// SetContextTimeout will give timeout to the calls made from now on
+func (u *usecase) SetContextTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&u.contextTimeout, int64(timeout))
+}
+
+func (u *usecase) timeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&u.contextTimeout))
+}
+
+// authorize will check the caller is an admin
+func authorize(ctx context.Context) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	if !p.Admin {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// generate will return a new key, made of a public prefix identifying it and a
+// secret part, separated by a dot
+func generate() (prefix string, key string, err error) {
+	b := make([]byte, 8+32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(b[:8])
+	return prefix, prefix + "." + base64.RawURLEncoding.EncodeToString(b[8:]), nil
+}
+
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (u *usecase) Fetch(c context.Context) ([]entities.APIKey, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err := authorize(ctx); err != nil {
+		return nil, err
+	}
+	return u.apiKeyRepo.Fetch(ctx)
+}
+
+func (u *usecase) Issue(c context.Context, k *entities.APIKey) (string, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err := authorize(ctx); err != nil {
+		return "", err
+	}
+	return u.issue(ctx, k)
+}
+
+func (u *usecase) issue(ctx context.Context, k *entities.APIKey) (string, error) {
+	prefix, key, err := generate()
+	if err != nil {
+		return "", err
+	}
+	k.Prefix = prefix
+	k.Hash = hash(key)
+	k.CreatedAt = time.Now()
+	k.RevokedAt = nil
+	if err = u.apiKeyRepo.Store(ctx, k); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (u *usecase) Revoke(c context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err := authorize(ctx); err != nil {
+		return err
+	}
+	return u.apiKeyRepo.Revoke(ctx, id, time.Now())
+}
+
+func (u *usecase) Rotate(c context.Context, id int64) (res entities.APIKey, key string, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err = authorize(ctx); err != nil {
+		return
+	}
+	old, err := u.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if old.RevokedAt != nil {
+		return entities.APIKey{}, "", domain.ErrNotFound
+	}
+
+	// the new key is issued first, the client is never left without a valid one
+	res = entities.APIKey{Name: old.Name, Scopes: old.Scopes, AuthorID: old.AuthorID}
+	if key, err = u.issue(ctx, &res); err != nil {
+		return entities.APIKey{}, "", err
+	}
+	if err = u.apiKeyRepo.Revoke(ctx, old.ID, res.CreatedAt); err != nil {
+		return entities.APIKey{}, "", err
+	}
+	return
+}
+
+func (u *usecase) Authenticate(c context.Context, key string) (entities.APIKey, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	i := strings.IndexByte(key, '.')
+	if i <= 0 {
+		return entities.APIKey{}, ErrInvalidKey
+	}
+	res, err := u.apiKeyRepo.GetByPrefix(ctx, key[:i])
+	if err == domain.ErrNotFound {
+		return entities.APIKey{}, ErrInvalidKey
+	}
+	if err != nil {
+		return entities.APIKey{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(key)), []byte(res.Hash)) != 1 || res.RevokedAt != nil {
+		return entities.APIKey{}, ErrInvalidKey
+	}
+	return res, nil
+}
@@ -0,0 +1,143 @@
+package apikey_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/usecases/apikey"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+)
+
+var adminCtx = domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "root", Admin: true})
+
+func TestIssue(t *testing.T) {
+	mockAPIKeyRepo := new(APIKeyRepository)
+
+	t.Run("success", func(t *testing.T) {
+		var stored entities.APIKey
+		mockAPIKeyRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.APIKey")).Run(func(args mock.Arguments) {
+			stored = *args.Get(1).(*entities.APIKey)
+		}).Return(nil).Once()
+		u := apikey.NewUsecase(mockAPIKeyRepo, time.Second*2)
+
+		k := entities.APIKey{Name: "importer", Scopes: []string{entities.ScopeArticlesWrite}, AuthorID: 1}
+		key, err := u.Issue(adminCtx, &k)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key, k.Prefix+"."))
+		assert.NotEmpty(t, stored.Hash)
+		assert.NotContains(t, stored.Hash, key)
+		assert.False(t, stored.CreatedAt.IsZero())
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+	t.Run("not-admin", func(t *testing.T) {
+		mockAPIKeyRepo := new(APIKeyRepository)
+		u := apikey.NewUsecase(mockAPIKeyRepo, time.Second*2)
+
+		_, err := u.Issue(context.TODO(), &entities.APIKey{Name: "importer"})
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1})
+		_, err = u.Issue(ctx, &entities.APIKey{Name: "importer"})
+		assert.True(t, errors.Is(err, domain.ErrForbidden))
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	mockAPIKeyRepo := new(APIKeyRepository)
+	var stored entities.APIKey
+	mockAPIKeyRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.APIKey")).Run(func(args mock.Arguments) {
+		stored = *args.Get(1).(*entities.APIKey)
+	}).Return(nil).Once()
+	u := apikey.NewUsecase(mockAPIKeyRepo, time.Second*2)
+	key, err := u.Issue(adminCtx, &entities.APIKey{Name: "reader", Scopes: []string{entities.ScopeArticlesRead}})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockAPIKeyRepo.On("GetByPrefix", mock.Anything, stored.Prefix).Return(stored, nil).Once()
+
+		res, err := u.Authenticate(context.TODO(), key)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored.Prefix, res.Prefix)
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+	t.Run("wrong-secret", func(t *testing.T) {
+		mockAPIKeyRepo.On("GetByPrefix", mock.Anything, stored.Prefix).Return(stored, nil).Once()
+
+		_, err := u.Authenticate(context.TODO(), stored.Prefix+".other")
+
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+	t.Run("revoked", func(t *testing.T) {
+		revoked := stored
+		now := time.Now()
+		revoked.RevokedAt = &now
+		mockAPIKeyRepo.On("GetByPrefix", mock.Anything, stored.Prefix).Return(revoked, nil).Once()
+
+		_, err := u.Authenticate(context.TODO(), key)
+
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		mockAPIKeyRepo.On("GetByPrefix", mock.Anything, "0000").Return(entities.APIKey{}, domain.ErrNotFound).Once()
+
+		_, err := u.Authenticate(context.TODO(), "0000.secret")
+
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := u.Authenticate(context.TODO(), "secret")
+
+		assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+	})
+}
+
+func TestRotate(t *testing.T) {
+	mockAPIKeyRepo := new(APIKeyRepository)
+	old := entities.APIKey{ID: 1, Name: "importer", Prefix: "0001", Scopes: []string{entities.ScopeArticlesWrite}, AuthorID: 2}
+
+	t.Run("success", func(t *testing.T) {
+		mockAPIKeyRepo.On("GetByID", mock.Anything, int64(1)).Return(old, nil).Once()
+		mockAPIKeyRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.APIKey")).Run(func(args mock.Arguments) {
+			args.Get(1).(*entities.APIKey).ID = 2
+		}).Return(nil).Once()
+		mockAPIKeyRepo.On("Revoke", mock.Anything, int64(1), mock.AnythingOfType("time.Time")).Return(nil).Once()
+		u := apikey.NewUsecase(mockAPIKeyRepo, time.Second*2)
+
+		res, key, err := u.Rotate(adminCtx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), res.ID)
+		assert.Equal(t, old.Name, res.Name)
+		assert.Equal(t, old.Scopes, res.Scopes)
+		assert.Equal(t, old.AuthorID, res.AuthorID)
+		assert.True(t, strings.HasPrefix(key, res.Prefix+"."))
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+	t.Run("revoked", func(t *testing.T) {
+		revoked := old
+		now := time.Now()
+		revoked.RevokedAt = &now
+		mockAPIKeyRepo.On("GetByID", mock.Anything, int64(1)).Return(revoked, nil).Once()
+		u := apikey.NewUsecase(mockAPIKeyRepo, time.Second*2)
+
+		_, _, err := u.Rotate(adminCtx, 1)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+}
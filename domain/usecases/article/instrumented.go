@@ -0,0 +1,83 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/metrics"
+)
+
+// instrumentedUsecase records the latency and the errors of the calls to a Usecase
+type instrumentedUsecase struct {
+	next    Usecase
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedUsecase will create an implementation of Usecase recording the calls to next with m
+func NewInstrumentedUsecase(next Usecase, m *metrics.Metrics) Usecase {
+	return &instrumentedUsecase{next: next, metrics: m}
+}
+
+func (u *instrumentedUsecase) observe(method string, start time.Time, err *error) {
+	u.metrics.ObserveCall(metrics.LayerUsecase, "article", method, start, *err)
+}
+
+func (u *instrumentedUsecase) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Article,
+	nextCursor string, prevCursor string, err error) {
+	defer u.observe("Fetch", time.Now(), &err)
+	return u.next.Fetch(ctx, cursor, num)
+}
+
+func (u *instrumentedUsecase) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	defer u.observe("FetchByCategory", time.Now(), &err)
+	return u.next.FetchByCategory(ctx, tag, cursor, num)
+}
+
+func (u *instrumentedUsecase) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	defer u.observe("FetchByAuthor", time.Now(), &err)
+	return u.next.FetchByAuthor(ctx, authorID, cursor, num)
+}
+
+func (u *instrumentedUsecase) GetByID(ctx context.Context, id int64) (res entities.Article, err error) {
+	defer u.observe("GetByID", time.Now(), &err)
+	return u.next.GetByID(ctx, id)
+}
+
+func (u *instrumentedUsecase) Update(ctx context.Context, ar *entities.Article) (err error) {
+	defer u.observe("Update", time.Now(), &err)
+	return u.next.Update(ctx, ar)
+}
+
+func (u *instrumentedUsecase) GetByTitle(ctx context.Context, title string) (res entities.Article, err error) {
+	defer u.observe("GetByTitle", time.Now(), &err)
+	return u.next.GetByTitle(ctx, title)
+}
+
+func (u *instrumentedUsecase) Search(ctx context.Context, query string, cursor string, num int64) (
+	res []entities.ArticleMatch, nextCursor string, err error) {
+	defer u.observe("Search", time.Now(), &err)
+	return u.next.Search(ctx, query, cursor, num)
+}
+
+func (u *instrumentedUsecase) Store(ctx context.Context, a *entities.Article) (err error) {
+	defer u.observe("Store", time.Now(), &err)
+	return u.next.Store(ctx, a)
+}
+
+func (u *instrumentedUsecase) Delete(ctx context.Context, id int64, version int64) (err error) {
+	defer u.observe("Delete", time.Now(), &err)
+	return u.next.Delete(ctx, id, version)
+}
+
+func (u *instrumentedUsecase) AttachCategory(ctx context.Context, id int64, categoryID int64) (err error) {
+	defer u.observe("AttachCategory", time.Now(), &err)
+	return u.next.AttachCategory(ctx, id, categoryID)
+}
+
+func (u *instrumentedUsecase) DetachCategory(ctx context.Context, id int64, categoryID int64) (err error) {
+	defer u.observe("DetachCategory", time.Now(), &err)
+	return u.next.DetachCategory(ctx, id, categoryID)
+}
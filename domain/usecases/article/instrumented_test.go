@@ -0,0 +1,32 @@
+package article_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/usecases/article"
+	"github.com/tolbier/go-clean-arch/lib/metrics"
+	ucase "github.com/tolbier/go-clean-arch/mocks/domain/usecases/article"
+)
+
+func TestInstrumentedUsecase(t *testing.T) {
+	m, err := metrics.New(prometheus.NewRegistry())
+	require.NoError(t, err)
+	mockUCase := new(ucase.Usecase)
+	u := article.NewInstrumentedUsecase(mockUCase, m)
+
+	mockUCase.On("Delete", mock.Anything, int64(1), int64(0)).Return(domain.ErrForbidden).Once()
+
+	err = u.Delete(context.TODO(), 1, 0)
+
+	assert.Equal(t, domain.ErrForbidden, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.CallErrors.WithLabelValues(metrics.LayerUsecase, "article", "Delete", "forbidden")))
+	mockUCase.AssertExpectations(t)
+}
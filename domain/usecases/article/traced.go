@@ -0,0 +1,88 @@
+package article
+
+import (
+	"context"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedUsecase records a span for each call to a Usecase
+type tracedUsecase struct {
+	next Usecase
+}
+
+// NewTracedUsecase will create an implementation of Usecase recording a span for each call to next
+func NewTracedUsecase(next Usecase) Usecase {
+	return &tracedUsecase{next: next}
+}
+
+func (u *tracedUsecase) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Article,
+	nextCursor string, prevCursor string, err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.Fetch")
+	defer tracing.End(span, &err)
+	return u.next.Fetch(ctx, cursor, num)
+}
+
+func (u *tracedUsecase) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.FetchByCategory")
+	defer tracing.End(span, &err)
+	return u.next.FetchByCategory(ctx, tag, cursor, num)
+}
+
+func (u *tracedUsecase) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.FetchByAuthor")
+	defer tracing.End(span, &err)
+	return u.next.FetchByAuthor(ctx, authorID, cursor, num)
+}
+
+func (u *tracedUsecase) GetByID(ctx context.Context, id int64) (res entities.Article, err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.GetByID")
+	defer tracing.End(span, &err)
+	return u.next.GetByID(ctx, id)
+}
+
+func (u *tracedUsecase) Update(ctx context.Context, ar *entities.Article) (err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.Update")
+	defer tracing.End(span, &err)
+	return u.next.Update(ctx, ar)
+}
+
+func (u *tracedUsecase) GetByTitle(ctx context.Context, title string) (res entities.Article, err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.GetByTitle")
+	defer tracing.End(span, &err)
+	return u.next.GetByTitle(ctx, title)
+}
+
+func (u *tracedUsecase) Search(ctx context.Context, query string, cursor string, num int64) (
+	res []entities.ArticleMatch, nextCursor string, err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.Search")
+	defer tracing.End(span, &err)
+	return u.next.Search(ctx, query, cursor, num)
+}
+
+func (u *tracedUsecase) Store(ctx context.Context, a *entities.Article) (err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.Store")
+	defer tracing.End(span, &err)
+	return u.next.Store(ctx, a)
+}
+
+func (u *tracedUsecase) Delete(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.Delete")
+	defer tracing.End(span, &err)
+	return u.next.Delete(ctx, id, version)
+}
+
+func (u *tracedUsecase) AttachCategory(ctx context.Context, id int64, categoryID int64) (err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.AttachCategory")
+	defer tracing.End(span, &err)
+	return u.next.AttachCategory(ctx, id, categoryID)
+}
+
+func (u *tracedUsecase) DetachCategory(ctx context.Context, id int64, categoryID int64) (err error) {
+	ctx, span := tracing.Start(ctx, "ArticleUsecase.DetachCategory")
+	defer tracing.End(span, &err)
+	return u.next.DetachCategory(ctx, id, categoryID)
+}
@@ -2,118 +2,245 @@ package article
 
 import (
     "context"
+    "errors"
 	"github.com/tolbier/go-clean-arch/domain"
 	"github.com/tolbier/go-clean-arch/domain/entities"
 	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/search"
+	"strings"
+	"sync/atomic"
 	"time"
-
-	"github.com/sirupsen/logrus"
-	"golang.org/x/sync/errgroup"
 )
 
 // Usecase represent the article's usecases
 type Usecase interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, error)
+	Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, string, error)
+	FetchByCategory(ctx context.Context, tag string, cursor string, num int64) ([]entities.Article, string, string, error)
+	FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) ([]entities.Article, string, string, error)
 	GetByID(ctx context.Context, id int64) (entities.Article, error)
+	// Update replaces the article. A non zero ar.Version is the version the caller
+	// based the change on, the update is refused with domain.ErrPreconditionFailed if
+	// the stored article has moved on since. Only the author of the article, as
+	// given by the domain.Principal of ctx, or an admin may change it.
 	Update(ctx context.Context, ar *entities.Article) error
 	GetByTitle(ctx context.Context, title string) (entities.Article, error)
+	// Search returns the articles matching query, the most relevant first,
+	// each with a highlighted snippet of its content
+	Search(ctx context.Context, query string, cursor string, num int64) ([]entities.ArticleMatch, string, error)
+	// Store creates the article, written by the caller unless an admin names another author.
+	// An unknown category refuses it with domain.ErrBadParamInput before anything is stored.
 	Store(context.Context, *entities.Article) error
-	Delete(ctx context.Context, id int64) error
+	// Delete removes the article. A non zero version makes the deletion
+	// conditional in the same way as Update, and only the same callers may delete it.
+	Delete(ctx context.Context, id int64, version int64) error
+	AttachCategory(ctx context.Context, id int64, categoryID int64) error
+	DetachCategory(ctx context.Context, id int64, categoryID int64) error
 }
 
 type usecase struct {
+	// contextTimeout is the time.Duration the calls are given, it is accessed
+	// atomically to be replaced while they run
+	contextTimeout int64
 	articleRepo    repositories.ArticleRepository
 	authorRepo     repositories.AuthorRepository
-	contextTimeout time.Duration
+	categoryRepo   repositories.CategoryRepository
 }
 
 // NewUsecase will create new an usecase object representation of domain.Usecase interface
-func NewUsecase(a repositories.ArticleRepository, ar repositories.AuthorRepository, timeout time.Duration) Usecase {
+func NewUsecase(a repositories.ArticleRepository, ar repositories.AuthorRepository, cr repositories.CategoryRepository,
+	timeout time.Duration) Usecase {
 	return &usecase{
 		articleRepo:    a,
 		authorRepo:     ar,
-		contextTimeout: timeout,
+		categoryRepo:   cr,
+		contextTimeout: int64(timeout),
 	}
 }
 
-/*
-* In this function below, I'm using errgroup with the pipeline pattern
-* Look how this works in this package explanation
-* in godoc: https://godoc.org/golang.org/x/sync/errgroup#ex-Group--Pipeline
- */
-func (a *usecase) fillAuthorDetails(c context.Context, data []entities.Article) ([]entities.Article, error) {
-	g, ctx := errgroup.WithContext(c)
+// SetContextTimeout will give timeout to the calls made from now on
+func (a *usecase) SetContextTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&a.contextTimeout, int64(timeout))
+}
 
-	// Get the author's id
-	mapAuthors := map[int64]entities.Author{}
+func (a *usecase) timeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&a.contextTimeout))
+}
 
-	for _, article := range data {
-		mapAuthors[article.Author.ID] = entities.Author{}
-	}
-	// Using goroutine to fetch the author's detail
-	chanAuthor := make(chan entities.Author)
-	for authorID := range mapAuthors {
-		authorID := authorID
-		g.Go(func() error {
-			res, err := a.authorRepo.GetByID(ctx, authorID)
-			if err != nil {
-				return err
-			}
-			chanAuthor <- res
-			return nil
-		})
+// fillAuthorDetails will load the authors of data with a single query. An author missing
+// from the storage, e.g. deleted without reassigning its articles, does not fail the
+// listing: the article keeps the bare reference holding the author's id.
+func (a *usecase) fillAuthorDetails(ctx context.Context, data []entities.Article) ([]entities.Article, error) {
+	if len(data) == 0 {
+		return data, nil
 	}
 
-	go func() {
-		err := g.Wait()
-		if err != nil {
-			logrus.Error(err)
-			return
+	ids := make([]int64, 0, len(data))
+	seen := make(map[int64]bool, len(data))
+	for _, item := range data {
+		if !seen[item.Author.ID] {
+			seen[item.Author.ID] = true
+			ids = append(ids, item.Author.ID)
 		}
-		close(chanAuthor)
-	}()
+	}
+
+	mapAuthors, err := a.authorRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
-	for author := range chanAuthor {
-		if author != (entities.Author{}) {
-			mapAuthors[author.ID] = author
+	// merge the author's data
+	for index, item := range data {
+		author, ok := mapAuthors[item.Author.ID]
+		if !ok {
+			logger.FromContext(ctx).Warnf("author %d of article %d not found", item.Author.ID, item.ID)
+			data[index].Author = entities.Author{ID: item.Author.ID}
+			continue
 		}
+		data[index].Author = author
+	}
+	return data, nil
+}
+
+func (a *usecase) fillCategoryDetails(ctx context.Context, data []entities.Article) ([]entities.Article, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	ids := make([]int64, len(data))
+	for index, item := range data {
+		ids[index] = item.ID
 	}
 
-	if err := g.Wait(); err != nil {
+	mapCategories, err := a.categoryRepo.GetByArticleIDs(ctx, ids)
+	if err != nil {
 		return nil, err
 	}
 
-	// merge the author's data
 	for index, item := range data {
-		if a, ok := mapAuthors[item.Author.ID]; ok {
-			data[index].Author = a
+		data[index].Categories = mapCategories[item.ID]
+		if data[index].Categories == nil {
+			data[index].Categories = []entities.Category{}
 		}
 	}
 	return data, nil
 }
 
-func (a *usecase) Fetch(c context.Context, cursor string, num int64) (res []entities.Article, nextCursor string, err error) {
+func (a *usecase) fillDetails(ctx context.Context, data []entities.Article) ([]entities.Article, error) {
+	data, err := a.fillAuthorDetails(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return a.fillCategoryDetails(ctx, data)
+}
+
+func (a *usecase) Fetch(c context.Context, cursor string, num int64) (res []entities.Article, nextCursor string, prevCursor string,
+	err error) {
 	if num == 0 {
 		num = 10
 	}
 
-	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	ctx, cancel := context.WithTimeout(c, a.timeout())
 	defer cancel()
 
-	res, nextCursor, err = a.articleRepo.Fetch(ctx, cursor, num)
+	res, nextCursor, prevCursor, err = a.articleRepo.Fetch(ctx, cursor, num)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	res, err = a.fillDetails(ctx, res)
+	if err != nil {
+		nextCursor, prevCursor = "", ""
+	}
+	return
+}
+
+func (a *usecase) Search(c context.Context, query string, cursor string, num int64) (res []entities.ArticleMatch, nextCursor string,
+	err error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, "", domain.ErrBadParamInput
+	}
+	if num == 0 {
+		num = 10
+	}
+
+	ctx, cancel := context.WithTimeout(c, a.timeout())
+	defer cancel()
+
+	res, nextCursor, err = a.articleRepo.Search(ctx, query, cursor, num)
+	if err != nil {
+		return nil, "", err
+	}
+
+	articles := make([]entities.Article, len(res))
+	for index, item := range res {
+		articles[index] = item.Article
+	}
+	articles, err = a.fillDetails(ctx, articles)
 	if err != nil {
 		return nil, "", err
 	}
 
-	res, err = a.fillAuthorDetails(ctx, res)
+	for index := range res {
+		res[index].Article = articles[index]
+		res[index].Snippet = search.Snippet(articles[index].Content, query)
+	}
+	return
+}
+
+func (a *usecase) FetchByCategory(c context.Context, tag string, cursor string, num int64) (res []entities.Article, nextCursor string,
+	prevCursor string, err error) {
+	if num == 0 {
+		num = 10
+	}
+
+	ctx, cancel := context.WithTimeout(c, a.timeout())
+	defer cancel()
+
+	res, nextCursor, prevCursor, err = a.articleRepo.FetchByCategory(ctx, tag, cursor, num)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	res, err = a.fillDetails(ctx, res)
+	if err != nil {
+		nextCursor, prevCursor = "", ""
+	}
+	return
+}
+
+func (a *usecase) FetchByAuthor(c context.Context, authorID int64, cursor string, num int64) (res []entities.Article, nextCursor string,
+	prevCursor string, err error) {
+	if num == 0 {
+		num = 10
+	}
+
+	ctx, cancel := context.WithTimeout(c, a.timeout())
+	defer cancel()
+
+	resAuthor, err := a.authorRepo.GetByID(ctx, authorID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	res, nextCursor, prevCursor, err = a.articleRepo.FetchByAuthor(ctx, authorID, cursor, num)
 	if err != nil {
-		nextCursor = ""
+		return nil, "", "", err
+	}
+
+	for index := range res {
+		res[index].Author = resAuthor
+	}
+
+	res, err = a.fillCategoryDetails(ctx, res)
+	if err != nil {
+		nextCursor, prevCursor = "", ""
 	}
 	return
 }
 
 func (a *usecase) GetByID(c context.Context, id int64) (res entities.Article, err error) {
-	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	ctx, cancel := context.WithTimeout(c, a.timeout())
 	defer cancel()
 
 	res, err = a.articleRepo.GetByID(ctx, id)
@@ -121,60 +248,165 @@ func (a *usecase) GetByID(c context.Context, id int64) (res entities.Article, er
 		return
 	}
 
-	resAuthor, err := a.authorRepo.GetByID(ctx, res.Author.ID)
+	list, err := a.fillDetails(ctx, []entities.Article{res})
 	if err != nil {
 		return entities.Article{}, err
 	}
-	res.Author = resAuthor
-	return
+	return list[0], nil
 }
 
 func (a *usecase) Update(c context.Context, ar *entities.Article) (err error) {
-	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	ctx, cancel := context.WithTimeout(c, a.timeout())
 	defer cancel()
 
+	existedArticle, err := a.articleRepo.GetByID(ctx, ar.ID)
+	if err != nil {
+		return
+	}
+	if err = authorize(ctx, existedArticle.Author.ID); err != nil {
+		return
+	}
+	if ar.Author.ID != 0 && ar.Author.ID != existedArticle.Author.ID {
+		if err = authorize(ctx, ar.Author.ID); err != nil {
+			return
+		}
+	}
+	if ar.Version != 0 && ar.Version != existedArticle.Version {
+		return domain.ErrPreconditionFailed
+	}
+	ar.Version = existedArticle.Version
+
+	titledArticle, err := a.articleRepo.GetByTitle(ctx, ar.Title)
+	if err == nil && titledArticle.ID != ar.ID {
+		return domain.ErrConflict
+	}
+	if err != nil && err != domain.ErrNotFound {
+		return
+	}
+
+	if ar.Author.ID == 0 {
+		ar.Author = existedArticle.Author
+	}
+	ar.CreatedAt = existedArticle.CreatedAt
 	ar.UpdatedAt = time.Now()
 	return a.articleRepo.Update(ctx, ar)
 }
 
 func (a *usecase) GetByTitle(c context.Context, title string) (res entities.Article, err error) {
-	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	ctx, cancel := context.WithTimeout(c, a.timeout())
 	defer cancel()
 	res, err = a.articleRepo.GetByTitle(ctx, title)
 	if err != nil {
 		return
 	}
 
-	resAuthor, err := a.authorRepo.GetByID(ctx, res.Author.ID)
+	list, err := a.fillDetails(ctx, []entities.Article{res})
 	if err != nil {
 		return entities.Article{}, err
 	}
-
-	res.Author = resAuthor
-	return
+	return list[0], nil
 }
 
 func (a *usecase) Store(c context.Context, m *entities.Article) (err error) {
-	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	ctx, cancel := context.WithTimeout(c, a.timeout())
 	defer cancel()
-	existedArticle, _ := a.GetByTitle(ctx, m.Title)
-	if existedArticle != (entities.Article{}) {
+	if m.Author.ID == 0 {
+		if p, ok := domain.PrincipalFromContext(ctx); ok {
+			m.Author.ID = p.AuthorID
+		}
+	}
+	if err = authorize(ctx, m.Author.ID); err != nil {
+		return
+	}
+	if _, err = a.GetByTitle(ctx, m.Title); err == nil {
 		return domain.ErrConflict
 	}
+	// the categories are checked first, not to leave a stored article behind
+	// when one of them is refused
+	for _, category := range m.Categories {
+		if _, err = a.categoryRepo.GetByID(ctx, category.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrBadParamInput.WithDetails(map[string]interface{}{"param": "categories"})
+			}
+			return
+		}
+	}
 
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
 	err = a.articleRepo.Store(ctx, m)
+	if err != nil {
+		return
+	}
+
+	for _, category := range m.Categories {
+		if err = a.categoryRepo.Attach(ctx, m.ID, category.ID); err != nil {
+			return
+		}
+	}
 	return
 }
 
-func (a *usecase) Delete(c context.Context, id int64) (err error) {
-	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+func (a *usecase) Delete(c context.Context, id int64, version int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.timeout())
 	defer cancel()
 	existedArticle, err := a.articleRepo.GetByID(ctx, id)
 	if err != nil {
 		return
 	}
-	if existedArticle == (entities.Article{}) {
+	if existedArticle.ID == 0 {
 		return domain.ErrNotFound
 	}
-	return a.articleRepo.Delete(ctx, id)
+	if err = authorize(ctx, existedArticle.Author.ID); err != nil {
+		return
+	}
+	if version != 0 && version != existedArticle.Version {
+		return domain.ErrPreconditionFailed
+	}
+	return a.articleRepo.Delete(ctx, id, existedArticle.Version)
+}
+
+func (a *usecase) AttachCategory(c context.Context, id int64, categoryID int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.timeout())
+	defer cancel()
+
+	existedArticle, err := a.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = authorize(ctx, existedArticle.Author.ID); err != nil {
+		return
+	}
+	if _, err = a.categoryRepo.GetByID(ctx, categoryID); err != nil {
+		return
+	}
+	return a.categoryRepo.Attach(ctx, id, categoryID)
+}
+
+func (a *usecase) DetachCategory(c context.Context, id int64, categoryID int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.timeout())
+	defer cancel()
+
+	existedArticle, err := a.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = authorize(ctx, existedArticle.Author.ID); err != nil {
+		return
+	}
+	return a.categoryRepo.Detach(ctx, id, categoryID)
+}
+
+// authorize will check the caller may write the articles of the given author:
+// the author itself or an admin
+func authorize(ctx context.Context, authorID int64) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	if !p.CanWriteAs(authorID) {
+		return domain.ErrForbidden
+	}
+	return nil
 }
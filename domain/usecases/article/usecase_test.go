@@ -14,6 +14,8 @@ import (
     "github.com/stretchr/testify/mock"
 )
 
+var adminCtx = domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "admin", Admin: true})
+
 func TestFetch(t *testing.T) {
 	mockArticleRepo := new(ArticleRepository)
 	mockArticle := entities.Article{
@@ -26,42 +28,50 @@ func TestFetch(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockArticleRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
-			mock.AnythingOfType("int64")).Return(mockListArtilce, "next-cursor", nil).Once()
+			mock.AnythingOfType("int64")).Return(mockListArtilce, "next-cursor", "prev-cursor", nil).Once()
 		mockAuthor := entities.Author{
 			ID:   1,
 			Name: "Iman Tumorang",
 		}
 		mockAuthorrepo := new(AuthorRepository)
-		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockAuthor, nil)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, mock.AnythingOfType("[]int64")).Return(map[int64][]entities.Category{}, nil).Once()
+		mockAuthorrepo.On("GetByIDs", mock.Anything, mock.AnythingOfType("[]int64")).
+			Return(map[int64]entities.Author{mockAuthor.ID: mockAuthor}, nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 		num := int64(1)
 		cursor := "12"
-		list, nextCursor, err := u.Fetch(context.TODO(), cursor, num)
+		list, nextCursor, prevCursor, err := u.Fetch(context.TODO(), cursor, num)
 		cursorExpected := "next-cursor"
 		assert.Equal(t, cursorExpected, nextCursor)
 		assert.NotEmpty(t, nextCursor)
+		assert.Equal(t, "prev-cursor", prevCursor)
 		assert.NoError(t, err)
 		assert.Len(t, list, len(mockListArtilce))
 
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
 
 	t.Run("error-failed", func(t *testing.T) {
 		mockArticleRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
-			mock.AnythingOfType("int64")).Return(nil, "", errors.New("Unexpexted Error")).Once()
+			mock.AnythingOfType("int64")).Return(nil, "", "", errors.New("Unexpexted Error")).Once()
 
 		mockAuthorrepo := new(AuthorRepository)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 		num := int64(1)
 		cursor := "12"
-		list, nextCursor, err := u.Fetch(context.TODO(), cursor, num)
+		list, nextCursor, prevCursor, err := u.Fetch(context.TODO(), cursor, num)
 
 		assert.Empty(t, nextCursor)
+		assert.Empty(t, prevCursor)
 		assert.Error(t, err)
 		assert.Len(t, list, 0)
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
 
 }
@@ -80,8 +90,11 @@ func TestGetByID(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
 		mockAuthorrepo := new(AuthorRepository)
-		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockAuthor, nil)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, mock.AnythingOfType("[]int64")).Return(map[int64][]entities.Category{}, nil).Once()
+		mockAuthorrepo.On("GetByIDs", mock.Anything, mock.AnythingOfType("[]int64")).
+			Return(map[int64]entities.Author{mockAuthor.ID: mockAuthor}, nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockArticle.ID)
 
@@ -90,12 +103,14 @@ func TestGetByID(t *testing.T) {
 
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
 	t.Run("error-failed", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(entities.Article{}, errors.New("Unexpected")).Once()
 
 		mockAuthorrepo := new(AuthorRepository)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockArticle.ID)
 
@@ -104,6 +119,7 @@ func TestGetByID(t *testing.T) {
 
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
 
 }
@@ -122,12 +138,14 @@ func TestStore(t *testing.T) {
 		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.Article")).Return(nil).Once()
 
 		mockAuthorrepo := new(AuthorRepository)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 
-		err := u.Store(context.TODO(), &tempMockArticle)
+		err := u.Store(adminCtx, &tempMockArticle)
 
 		assert.NoError(t, err)
 		assert.Equal(t, mockArticle.Title, tempMockArticle.Title)
+		assert.False(t, tempMockArticle.CreatedAt.IsZero())
 		mockArticleRepo.AssertExpectations(t)
 	})
 	t.Run("existing-title", func(t *testing.T) {
@@ -138,15 +156,58 @@ func TestStore(t *testing.T) {
 			Name: "Iman Tumorang",
 		}
 		mockAuthorrepo := new(AuthorRepository)
-		mockAuthorrepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockAuthor, nil)
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, mock.AnythingOfType("[]int64")).Return(map[int64][]entities.Category{}, nil).Once()
+		mockAuthorrepo.On("GetByIDs", mock.Anything, mock.AnythingOfType("[]int64")).
+			Return(map[int64]entities.Author{mockAuthor.ID: mockAuthor}, nil).Once()
 
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 
-		err := u.Store(context.TODO(), &mockArticle)
+		err := u.Store(adminCtx, &mockArticle)
 
 		assert.Error(t, err)
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("with-categories", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.ID = 0
+		tempMockArticle.Categories = []entities.Category{{ID: 1}}
+		mockArticleRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(entities.Article{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.Article")).Return(nil).Once()
+
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Category{ID: 1}, nil).Once()
+		mockCategoryRepo.On("Attach", mock.Anything, mock.AnythingOfType("int64"), int64(1)).Return(nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.Store(adminCtx, &tempMockArticle)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("unknown-category", func(t *testing.T) {
+		mockArticleRepo := new(ArticleRepository)
+		tempMockArticle := mockArticle
+		tempMockArticle.ID = 0
+		tempMockArticle.Categories = []entities.Category{{ID: 1}, {ID: 9}}
+		mockArticleRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(entities.Article{}, domain.ErrNotFound).Once()
+
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Category{ID: 1}, nil).Once()
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(9)).Return(entities.Category{}, domain.ErrNotFound).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.Store(adminCtx, &tempMockArticle)
+
+		assert.True(t, errors.Is(err, domain.ErrBadParamInput))
+		// nothing is stored
+		mockArticleRepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
 
 }
@@ -156,45 +217,65 @@ func TestDelete(t *testing.T) {
 	mockArticle := entities.Article{
 		Title:   "Hello",
 		Content: "Content",
+		ID:      23,
+		Version: 2,
 	}
 
 	t.Run("success", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
 
-		mockArticleRepo.On("Delete", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
+		mockArticleRepo.On("Delete", mock.Anything, mock.AnythingOfType("int64"), mockArticle.Version).Return(nil).Once()
 
 		mockAuthorrepo := new(AuthorRepository)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 
-		err := u.Delete(context.TODO(), mockArticle.ID)
+		err := u.Delete(adminCtx, mockArticle.ID, 0)
 
 		assert.NoError(t, err)
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("stale-version", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
+
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.Delete(adminCtx, mockArticle.ID, mockArticle.Version+1)
+
+		assert.Equal(t, domain.ErrPreconditionFailed, err)
+		mockArticleRepo.AssertExpectations(t)
 	})
 	t.Run("article-is-not-exist", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(entities.Article{}, nil).Once()
 
 		mockAuthorrepo := new(AuthorRepository)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 
-		err := u.Delete(context.TODO(), mockArticle.ID)
+		err := u.Delete(adminCtx, mockArticle.ID, 0)
 
 		assert.Error(t, err)
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
 	t.Run("error-happens-in-db", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(entities.Article{}, errors.New("Unexpected Error")).Once()
 
 		mockAuthorrepo := new(AuthorRepository)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
 
-		err := u.Delete(context.TODO(), mockArticle.ID)
+		err := u.Delete(adminCtx, mockArticle.ID, 0)
 
 		assert.Error(t, err)
 		mockArticleRepo.AssertExpectations(t)
 		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
 
 }
@@ -208,13 +289,375 @@ func TestUpdate(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, mockArticle.Title).Return(mockArticle, nil).Once()
 		mockArticleRepo.On("Update", mock.Anything, &mockArticle).Once().Return(nil)
 
 		mockAuthorrepo := new(AuthorRepository)
-		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, time.Second*2)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.Update(adminCtx, &mockArticle)
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("article-is-not-exist", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(entities.Article{}, domain.ErrNotFound).Once()
+
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.Update(adminCtx, &tempMockArticle)
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("stale-version", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.Version = 1
+		storedArticle := mockArticle
+		storedArticle.Version = 2
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(storedArticle, nil).Once()
+
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.Update(adminCtx, &tempMockArticle)
+		assert.Equal(t, domain.ErrPreconditionFailed, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("title-taken-by-another-article", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		anotherArticle := mockArticle
+		anotherArticle.ID = 24
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, mockArticle.Title).Return(anotherArticle, nil).Once()
+
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.Update(adminCtx, &tempMockArticle)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
+
+func TestFetchByCategory(t *testing.T) {
+	mockArticleRepo := new(ArticleRepository)
+	mockArticle := entities.Article{
+		ID:      1,
+		Title:   "Hello",
+		Content: "Content",
+		Author:  entities.Author{ID: 1},
+	}
+	mockCategory := entities.Category{
+		ID:   1,
+		Name: "Makanan",
+		Tag:  "food",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("FetchByCategory", mock.Anything, "food", mock.AnythingOfType("string"),
+			mock.AnythingOfType("int64")).Return([]entities.Article{mockArticle}, "next-cursor", "", nil).Once()
+		mockAuthorrepo := new(AuthorRepository)
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).
+			Return(map[int64]entities.Author{1: {ID: 1, Name: "Iman Tumorang"}}, nil).Once()
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{1}).
+			Return(map[int64][]entities.Category{1: {mockCategory}}, nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		list, nextCursor, _, err := u.FetchByCategory(context.TODO(), "food", "", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "next-cursor", nextCursor)
+		assert.Len(t, list, 1)
+		assert.Equal(t, []entities.Category{mockCategory}, list[0].Categories)
+		assert.Equal(t, "Iman Tumorang", list[0].Author.Name)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestAttachCategory(t *testing.T) {
+	mockArticleRepo := new(ArticleRepository)
+	mockArticle := entities.Article{
+		ID:      1,
+		Title:   "Hello",
+		Content: "Content",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(mockArticle, nil).Once()
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(2)).Return(entities.Category{ID: 2}, nil).Once()
+		mockCategoryRepo.On("Attach", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.AttachCategory(adminCtx, 1, 2)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("category-is-not-exist", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(mockArticle, nil).Once()
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(2)).Return(entities.Category{}, domain.ErrNotFound).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		err := u.AttachCategory(adminCtx, 1, 2)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestFetchByAuthor(t *testing.T) {
+	mockArticleRepo := new(ArticleRepository)
+	mockArticle := entities.Article{
+		ID:      1,
+		Title:   "Hello",
+		Content: "Content",
+		Author:  entities.Author{ID: 1},
+	}
+	mockAuthor := entities.Author{
+		ID:   1,
+		Name: "Iman Tumorang",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorrepo := new(AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+		mockArticleRepo.On("FetchByAuthor", mock.Anything, int64(1), "", int64(10)).
+			Return([]entities.Article{mockArticle}, "", "", nil).Once()
+		mockCategoryRepo := new(CategoryRepository)
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{1}).Return(map[int64][]entities.Category{}, nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		list, _, _, err := u.FetchByAuthor(context.TODO(), 1, "", 0)
 
-		err := u.Update(context.TODO(), &mockArticle)
 		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Equal(t, mockAuthor, list[0].Author)
 		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
 	})
+	t.Run("author-is-not-exist", func(t *testing.T) {
+		mockAuthorrepo := new(AuthorRepository)
+		mockAuthorrepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Author{}, domain.ErrNotFound).Once()
+		mockCategoryRepo := new(CategoryRepository)
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		list, _, _, err := u.FetchByAuthor(context.TODO(), 1, "", 0)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Len(t, list, 0)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	mockMatches := []entities.ArticleMatch{
+		{
+			Article: entities.Article{ID: 2, Title: "Makan Ayam", Content: "<p>Ayam goreng</p>", Author: entities.Author{ID: 1}},
+			Score:   1.5,
+		},
+	}
+	mockAuthor := entities.Author{
+		ID:   1,
+		Name: "Iman Tumorang",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo := new(ArticleRepository)
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		mockArticleRepo.On("Search", mock.Anything, "ayam", "", int64(10)).Return(mockMatches, "next-cursor", nil).Once()
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1}).Return(map[int64]entities.Author{1: mockAuthor}, nil).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{2}).Return(map[int64][]entities.Category{}, nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		list, nextCursor, err := u.Search(context.TODO(), "ayam", "", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "next-cursor", nextCursor)
+		assert.Len(t, list, 1)
+		assert.Equal(t, mockAuthor, list[0].Author)
+		assert.Equal(t, 1.5, list[0].Score)
+		assert.Equal(t, "<mark>Ayam</mark> goreng", list[0].Snippet)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-empty-query", func(t *testing.T) {
+		mockArticleRepo := new(ArticleRepository)
+		u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+		_, _, err := u.Search(context.TODO(), "  ", "", 10)
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepo := new(ArticleRepository)
+		mockArticleRepo.On("Search", mock.Anything, "ayam", "", int64(10)).Return(nil, "", errors.New("Unexpexted Error")).Once()
+		u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+		list, nextCursor, err := u.Search(context.TODO(), "ayam", "", 10)
+
+		assert.Error(t, err)
+		assert.Empty(t, nextCursor)
+		assert.Len(t, list, 0)
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
+
+func TestFetchAuthorDetails(t *testing.T) {
+	mockListArticle := []entities.Article{
+		{ID: 1, Title: "Hello", Author: entities.Author{ID: 1}},
+		{ID: 2, Title: "World", Author: entities.Author{ID: 2}},
+		{ID: 3, Title: "Again", Author: entities.Author{ID: 1}},
+	}
+
+	t.Run("missing-author", func(t *testing.T) {
+		mockArticleRepo := new(ArticleRepository)
+		mockAuthorrepo := new(AuthorRepository)
+		mockCategoryRepo := new(CategoryRepository)
+		mockArticleRepo.On("Fetch", mock.Anything, "", int64(10)).
+			Return(append([]entities.Article(nil), mockListArticle...), "", "", nil).Once()
+		// one query for the distinct authors, author 2 is gone
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1, 2}).
+			Return(map[int64]entities.Author{1: {ID: 1, Name: "Iman Tumorang"}}, nil).Once()
+		mockCategoryRepo.On("GetByArticleIDs", mock.Anything, []int64{1, 2, 3}).Return(map[int64][]entities.Category{}, nil).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, mockCategoryRepo, time.Second*2)
+
+		list, _, _, err := u.Fetch(context.TODO(), "", 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, list, 3)
+		assert.Equal(t, "Iman Tumorang", list[0].Author.Name)
+		assert.Equal(t, entities.Author{ID: 2}, list[1].Author)
+		assert.Equal(t, "Iman Tumorang", list[2].Author.Name)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepo := new(ArticleRepository)
+		mockAuthorrepo := new(AuthorRepository)
+		mockArticleRepo.On("Fetch", mock.Anything, "", int64(10)).
+			Return(append([]entities.Article(nil), mockListArticle...), "next-cursor", "prev-cursor", nil).Once()
+		mockAuthorrepo.On("GetByIDs", mock.Anything, []int64{1, 2}).Return(nil, errors.New("Unexpected Error")).Once()
+		u := article.NewUsecase(mockArticleRepo, mockAuthorrepo, new(CategoryRepository), time.Second*2)
+
+		list, nextCursor, prevCursor, err := u.Fetch(context.TODO(), "", 10)
+
+		assert.Error(t, err)
+		assert.Empty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Len(t, list, 0)
+		mockArticleRepo.AssertExpectations(t)
+		mockAuthorrepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	mockArticle := entities.Article{
+		ID:      23,
+		Title:   "Hello",
+		Content: "Content",
+		Author:  entities.Author{ID: 1},
+	}
+	authorCtx := domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1})
+	otherCtx := domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "2", AuthorID: 2})
+
+	t.Run("author", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		mockArticleRepo := new(ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Twice()
+		mockArticleRepo.On("GetByTitle", mock.Anything, mockArticle.Title).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("Update", mock.Anything, &tempMockArticle).Return(nil).Once()
+		mockArticleRepo.On("Delete", mock.Anything, mockArticle.ID, mockArticle.Version).Return(nil).Once()
+		u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+		assert.NoError(t, u.Update(authorCtx, &tempMockArticle))
+		assert.NoError(t, u.Delete(authorCtx, mockArticle.ID, 0))
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("store-as-caller", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.ID = 0
+		tempMockArticle.Author = entities.Author{}
+		mockArticleRepo := new(ArticleRepository)
+		mockArticleRepo.On("GetByTitle", mock.Anything, mockArticle.Title).Return(entities.Article{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("Store", mock.Anything, &tempMockArticle).Return(nil).Once()
+		u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+		assert.NoError(t, u.Store(otherCtx, &tempMockArticle))
+		assert.Equal(t, int64(2), tempMockArticle.Author.ID)
+		assert.Equal(t, domain.ErrForbidden, u.Store(otherCtx, &entities.Article{Title: "Hi", Author: entities.Author{ID: 1}}))
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("another-author", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		mockArticleRepo := new(ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Times(4)
+		u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+		assert.Equal(t, domain.ErrForbidden, u.Update(otherCtx, &tempMockArticle))
+		assert.Equal(t, domain.ErrForbidden, u.Delete(otherCtx, mockArticle.ID, 0))
+		assert.Equal(t, domain.ErrForbidden, u.AttachCategory(otherCtx, mockArticle.ID, 2))
+		assert.Equal(t, domain.ErrForbidden, u.DetachCategory(otherCtx, mockArticle.ID, 2))
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("give-away", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.Author = entities.Author{ID: 2}
+		mockArticleRepo := new(ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+		assert.Equal(t, domain.ErrForbidden, u.Update(authorCtx, &tempMockArticle))
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("anonymous", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		mockArticleRepo := new(ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Twice()
+		u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+		assert.Equal(t, domain.ErrUnauthorized, u.Store(context.TODO(), &entities.Article{Title: "Hi"}))
+		assert.Equal(t, domain.ErrUnauthorized, u.Update(context.TODO(), &tempMockArticle))
+		assert.Equal(t, domain.ErrUnauthorized, u.Delete(context.TODO(), mockArticle.ID, 0))
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
+
+func TestSetContextTimeout(t *testing.T) {
+	mockArticleRepo := new(ArticleRepository)
+	var deadline time.Time
+	mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).
+		Run(func(args mock.Arguments) { deadline, _ = args.Get(0).(context.Context).Deadline() }).
+		Return(entities.Article{}, domain.ErrNotFound).Once()
+	u := article.NewUsecase(mockArticleRepo, new(AuthorRepository), new(CategoryRepository), time.Second*2)
+
+	u.(interface{ SetContextTimeout(time.Duration) }).SetContextTimeout(time.Minute)
+	_, err := u.GetByID(context.TODO(), 1)
+
+	assert.Error(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	mockArticleRepo.AssertExpectations(t)
 }
@@ -0,0 +1,49 @@
+package author
+
+import (
+	"context"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedUsecase records a span for each call to a Usecase
+type tracedUsecase struct {
+	next Usecase
+}
+
+// NewTracedUsecase will create an implementation of Usecase recording a span for each call to next
+func NewTracedUsecase(next Usecase) Usecase {
+	return &tracedUsecase{next: next}
+}
+
+func (u *tracedUsecase) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Author,
+	nextCursor string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.Fetch")
+	defer tracing.End(span, &err)
+	return u.next.Fetch(ctx, cursor, num)
+}
+
+func (u *tracedUsecase) GetByID(ctx context.Context, id int64) (res entities.Author, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.GetByID")
+	defer tracing.End(span, &err)
+	return u.next.GetByID(ctx, id)
+}
+
+func (u *tracedUsecase) Store(ctx context.Context, a *entities.Author) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.Store")
+	defer tracing.End(span, &err)
+	return u.next.Store(ctx, a)
+}
+
+func (u *tracedUsecase) Update(ctx context.Context, a *entities.Author) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.Update")
+	defer tracing.End(span, &err)
+	return u.next.Update(ctx, a)
+}
+
+func (u *tracedUsecase) Delete(ctx context.Context, id int64, reassignTo int64) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.Delete")
+	defer tracing.End(span, &err)
+	return u.next.Delete(ctx, id, reassignTo)
+}
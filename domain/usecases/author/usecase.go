@@ -0,0 +1,134 @@
+package author
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+)
+
+// Usecase represent the author's usecases
+type Usecase interface {
+	Fetch(ctx context.Context, cursor string, num int64) ([]entities.Author, string, error)
+	GetByID(ctx context.Context, id int64) (entities.Author, error)
+	Store(ctx context.Context, a *entities.Author) error
+	// Update replaces the author. Only the author itself, as given by the
+	// domain.Principal of ctx, or an admin may change it.
+	Update(ctx context.Context, a *entities.Author) error
+	// Delete removes the author. When reassignTo is not zero the author's articles
+	// are moved to that author first, otherwise an author owning articles is refused.
+	// Only the same callers as for Update may delete it, and only an admin may
+	// reassign the articles to another author.
+	Delete(ctx context.Context, id int64, reassignTo int64) error
+}
+
+type usecase struct {
+	// contextTimeout is the time.Duration the calls are given, it is accessed
+	// atomically to be replaced while they run
+	contextTimeout int64
+	authorRepo     repositories.AuthorRepository
+}
+
+// NewUsecase will create new an usecase object representation of author.Usecase interface
+func NewUsecase(ar repositories.AuthorRepository, timeout time.Duration) Usecase {
+	return &usecase{
+		authorRepo:     ar,
+		contextTimeout: int64(timeout),
+	}
+}
+
+// SetContextTimeout will give timeout to the calls made from now on
+func (u *usecase) SetContextTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&u.contextTimeout, int64(timeout))
+}
+
+func (u *usecase) timeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&u.contextTimeout))
+}
+
+func (u *usecase) Fetch(c context.Context, cursor string, num int64) (res []entities.Author, nextCursor string, err error) {
+	if num == 0 {
+		num = 10
+	}
+
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	return u.authorRepo.Fetch(ctx, cursor, num)
+}
+
+func (u *usecase) GetByID(c context.Context, id int64) (entities.Author, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	return u.authorRepo.GetByID(ctx, id)
+}
+
+func (u *usecase) Store(c context.Context, m *entities.Author) error {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+	return u.authorRepo.Store(ctx, m)
+}
+
+func (u *usecase) Update(c context.Context, m *entities.Author) error {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err := authorize(ctx, m.ID); err != nil {
+		return err
+	}
+	current, err := u.authorRepo.GetByID(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
+	m.CreatedAt = current.CreatedAt
+	m.UpdatedAt = time.Now()
+	return u.authorRepo.Update(ctx, m)
+}
+
+func (u *usecase) Delete(c context.Context, id int64, reassignTo int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err = authorize(ctx, id); err != nil {
+		return
+	}
+	if _, err = u.authorRepo.GetByID(ctx, id); err != nil {
+		return
+	}
+
+	if reassignTo != 0 {
+		if reassignTo == id {
+			return domain.ErrBadParamInput
+		}
+		// the articles may only be given to an author the caller writes as
+		if err = authorize(ctx, reassignTo); err != nil {
+			return
+		}
+		if _, err = u.authorRepo.GetByID(ctx, reassignTo); err != nil {
+			return
+		}
+	}
+	return u.authorRepo.Delete(ctx, id, reassignTo)
+}
+
+// authorize will check the caller may change the given author: the author
+// itself or an admin
+func authorize(ctx context.Context, authorID int64) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	if !p.CanWriteAs(authorID) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
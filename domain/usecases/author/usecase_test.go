@@ -0,0 +1,178 @@
+package author_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/usecases/author"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+)
+
+var adminCtx = domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "admin", Admin: true})
+
+func TestFetch(t *testing.T) {
+	mockAuthorRepo := new(AuthorRepository)
+	mockAuthor := entities.Author{
+		ID:   1,
+		Name: "Iman Tumorang",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepo.On("Fetch", mock.Anything, "", int64(10)).Return([]entities.Author{mockAuthor}, "next-cursor", nil).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		list, nextCursor, err := u.Fetch(context.TODO(), "", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "next-cursor", nextCursor)
+		assert.Len(t, list, 1)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore(t *testing.T) {
+	mockAuthorRepo := new(AuthorRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthor := entities.Author{Name: "Iman Tumorang"}
+		mockAuthorRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.Author")).Return(nil).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Store(context.TODO(), &mockAuthor)
+
+		assert.NoError(t, err)
+		assert.False(t, mockAuthor.CreatedAt.IsZero())
+		mockAuthorRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	mockAuthorRepo := new(AuthorRepository)
+	createdAt := time.Now().Add(-time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthor := entities.Author{ID: 1, Name: "Iman"}
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Author{ID: 1, CreatedAt: createdAt}, nil).Once()
+		mockAuthorRepo.On("Update", mock.Anything, &mockAuthor).Return(nil).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Update(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1}), &mockAuthor)
+
+		assert.NoError(t, err)
+		assert.Equal(t, createdAt, mockAuthor.CreatedAt)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("author-is-not-exist", func(t *testing.T) {
+		mockAuthor := entities.Author{ID: 1, Name: "Iman"}
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Author{}, domain.ErrNotFound).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Update(adminCtx, &mockAuthor)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("error-forbidden", func(t *testing.T) {
+		mockAuthor := entities.Author{ID: 1, Name: "Iman"}
+		mockAuthorRepo := new(AuthorRepository)
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Update(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "2", AuthorID: 2}), &mockAuthor)
+
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("error-unauthorized", func(t *testing.T) {
+		mockAuthor := entities.Author{ID: 1, Name: "Iman"}
+		mockAuthorRepo := new(AuthorRepository)
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Update(context.TODO(), &mockAuthor)
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	mockAuthor := entities.Author{ID: 1, Name: "Iman Tumorang"}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepo := new(AuthorRepository)
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+		mockAuthorRepo.On("Delete", mock.Anything, int64(1), int64(0)).Return(nil).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Delete(adminCtx, 1, 0)
+
+		assert.NoError(t, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("author-has-articles", func(t *testing.T) {
+		mockAuthorRepo := new(AuthorRepository)
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+		mockAuthorRepo.On("Delete", mock.Anything, int64(1), int64(0)).Return(domain.ErrAuthorHasArticles).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Delete(adminCtx, 1, 0)
+
+		assert.Equal(t, domain.ErrAuthorHasArticles, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("reassign-articles", func(t *testing.T) {
+		mockAuthorRepo := new(AuthorRepository)
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(2)).Return(entities.Author{ID: 2}, nil).Once()
+		mockAuthorRepo.On("Delete", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Delete(adminCtx, 1, 2)
+
+		assert.NoError(t, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("reassign-to-self", func(t *testing.T) {
+		mockAuthorRepo := new(AuthorRepository)
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Delete(adminCtx, 1, 1)
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("error-forbidden", func(t *testing.T) {
+		mockAuthorRepo := new(AuthorRepository)
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Delete(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "2", AuthorID: 2}), 1, 2)
+
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("error-reassign-forbidden", func(t *testing.T) {
+		mockAuthorRepo := new(AuthorRepository)
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Delete(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1}), 1, 2)
+
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+	t.Run("error-unauthorized", func(t *testing.T) {
+		mockAuthorRepo := new(AuthorRepository)
+		u := author.NewUsecase(mockAuthorRepo, time.Second*2)
+
+		err := u.Delete(context.TODO(), 1, 0)
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockAuthorRepo.AssertExpectations(t)
+	})
+}
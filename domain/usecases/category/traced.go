@@ -0,0 +1,54 @@
+package category
+
+import (
+	"context"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedUsecase records a span for each call to a Usecase
+type tracedUsecase struct {
+	next Usecase
+}
+
+// NewTracedUsecase will create an implementation of Usecase recording a span for each call to next
+func NewTracedUsecase(next Usecase) Usecase {
+	return &tracedUsecase{next: next}
+}
+
+func (u *tracedUsecase) Fetch(ctx context.Context) (res []entities.Category, err error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.Fetch")
+	defer tracing.End(span, &err)
+	return u.next.Fetch(ctx)
+}
+
+func (u *tracedUsecase) GetByID(ctx context.Context, id int64) (res entities.Category, err error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.GetByID")
+	defer tracing.End(span, &err)
+	return u.next.GetByID(ctx, id)
+}
+
+func (u *tracedUsecase) GetByTag(ctx context.Context, tag string) (res entities.Category, err error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.GetByTag")
+	defer tracing.End(span, &err)
+	return u.next.GetByTag(ctx, tag)
+}
+
+func (u *tracedUsecase) Store(ctx context.Context, c *entities.Category) (err error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.Store")
+	defer tracing.End(span, &err)
+	return u.next.Store(ctx, c)
+}
+
+func (u *tracedUsecase) Update(ctx context.Context, c *entities.Category) (err error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.Update")
+	defer tracing.End(span, &err)
+	return u.next.Update(ctx, c)
+}
+
+func (u *tracedUsecase) Delete(ctx context.Context, id int64) (err error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.Delete")
+	defer tracing.End(span, &err)
+	return u.next.Delete(ctx, id)
+}
@@ -0,0 +1,138 @@
+package category
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+)
+
+// Usecase represent the category's usecases. Only the admins may store, update
+// and delete the categories.
+type Usecase interface {
+	Fetch(ctx context.Context) ([]entities.Category, error)
+	GetByID(ctx context.Context, id int64) (entities.Category, error)
+	GetByTag(ctx context.Context, tag string) (entities.Category, error)
+	Store(ctx context.Context, c *entities.Category) error
+	Update(ctx context.Context, c *entities.Category) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type usecase struct {
+	// contextTimeout is the time.Duration the calls are given, it is accessed
+	// atomically to be replaced while they run
+	contextTimeout int64
+	categoryRepo   repositories.CategoryRepository
+}
+
+// NewUsecase will create new an usecase object representation of category.Usecase interface
+func NewUsecase(c repositories.CategoryRepository, timeout time.Duration) Usecase {
+	return &usecase{
+		categoryRepo:   c,
+		contextTimeout: int64(timeout),
+	}
+}
+
+// SetContextTimeout will give timeout to the calls made from now on
+func (u *usecase) SetContextTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&u.contextTimeout, int64(timeout))
+}
+
+func (u *usecase) timeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&u.contextTimeout))
+}
+
+func (u *usecase) Fetch(c context.Context) ([]entities.Category, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	return u.categoryRepo.Fetch(ctx)
+}
+
+func (u *usecase) GetByID(c context.Context, id int64) (entities.Category, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	return u.categoryRepo.GetByID(ctx, id)
+}
+
+func (u *usecase) GetByTag(c context.Context, tag string) (entities.Category, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	return u.categoryRepo.GetByTag(ctx, tag)
+}
+
+func (u *usecase) Store(c context.Context, m *entities.Category) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err = authorize(ctx); err != nil {
+		return
+	}
+	existed, err := u.categoryRepo.GetByTag(ctx, m.Tag)
+	if err == nil && existed.ID != 0 {
+		return domain.ErrConflict
+	}
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+	return u.categoryRepo.Store(ctx, m)
+}
+
+func (u *usecase) Update(c context.Context, m *entities.Category) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err = authorize(ctx); err != nil {
+		return
+	}
+	current, err := u.categoryRepo.GetByID(ctx, m.ID)
+	if err != nil {
+		return
+	}
+
+	existed, err := u.categoryRepo.GetByTag(ctx, m.Tag)
+	if err == nil && existed.ID != m.ID {
+		return domain.ErrConflict
+	}
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+
+	m.CreatedAt = current.CreatedAt
+	m.UpdatedAt = time.Now()
+	return u.categoryRepo.Update(ctx, m)
+}
+
+func (u *usecase) Delete(c context.Context, id int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout())
+	defer cancel()
+
+	if err = authorize(ctx); err != nil {
+		return
+	}
+	if _, err = u.categoryRepo.GetByID(ctx, id); err != nil {
+		return
+	}
+	return u.categoryRepo.Delete(ctx, id)
+}
+
+// authorize will check the caller is an admin
+func authorize(ctx context.Context) error {
+	p, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	if !p.Admin {
+		return domain.ErrForbidden
+	}
+	return nil
+}
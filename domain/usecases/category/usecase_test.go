@@ -0,0 +1,191 @@
+package category_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/usecases/category"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+)
+
+var adminCtx = domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "admin", Admin: true})
+
+func TestFetch(t *testing.T) {
+	mockCategoryRepo := new(CategoryRepository)
+	mockCategory := entities.Category{
+		ID:   1,
+		Name: "Makanan",
+		Tag:  "food",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockCategoryRepo.On("Fetch", mock.Anything).Return([]entities.Category{mockCategory}, nil).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		list, err := u.Fetch(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("error-failed", func(t *testing.T) {
+		mockCategoryRepo.On("Fetch", mock.Anything).Return(nil, errors.New("Unexpected Error")).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		list, err := u.Fetch(context.TODO())
+
+		assert.Error(t, err)
+		assert.Len(t, list, 0)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore(t *testing.T) {
+	mockCategoryRepo := new(CategoryRepository)
+	mockCategory := entities.Category{
+		Name: "Makanan",
+		Tag:  "food",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		tempMockCategory := mockCategory
+		mockCategoryRepo.On("GetByTag", mock.Anything, "food").Return(entities.Category{}, domain.ErrNotFound).Once()
+		mockCategoryRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.Category")).Return(nil).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Store(adminCtx, &tempMockCategory)
+
+		assert.NoError(t, err)
+		assert.False(t, tempMockCategory.CreatedAt.IsZero())
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("existing-tag", func(t *testing.T) {
+		existingCategory := mockCategory
+		existingCategory.ID = 1
+		mockCategoryRepo.On("GetByTag", mock.Anything, "food").Return(existingCategory, nil).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Store(adminCtx, &mockCategory)
+
+		assert.Equal(t, domain.ErrConflict, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("error-forbidden", func(t *testing.T) {
+		mockCategoryRepo := new(CategoryRepository)
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Store(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1}), &entities.Category{Name: "Makanan", Tag: "food"})
+
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("error-unauthorized", func(t *testing.T) {
+		mockCategoryRepo := new(CategoryRepository)
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Store(context.TODO(), &entities.Category{Name: "Makanan", Tag: "food"})
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	mockCategoryRepo := new(CategoryRepository)
+	mockCategory := entities.Category{
+		ID:   1,
+		Name: "Makanan",
+		Tag:  "food",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		tempMockCategory := mockCategory
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(1)).Return(mockCategory, nil).Once()
+		mockCategoryRepo.On("GetByTag", mock.Anything, "food").Return(mockCategory, nil).Once()
+		mockCategoryRepo.On("Update", mock.Anything, &tempMockCategory).Return(nil).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Update(adminCtx, &tempMockCategory)
+
+		assert.NoError(t, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("tag-taken-by-another-category", func(t *testing.T) {
+		tempMockCategory := mockCategory
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(1)).Return(mockCategory, nil).Once()
+		mockCategoryRepo.On("GetByTag", mock.Anything, "food").Return(entities.Category{ID: 2, Tag: "food"}, nil).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Update(adminCtx, &tempMockCategory)
+
+		assert.Equal(t, domain.ErrConflict, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("error-forbidden", func(t *testing.T) {
+		mockCategoryRepo := new(CategoryRepository)
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Update(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1}), &entities.Category{ID: 1, Name: "Makanan", Tag: "food"})
+
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("error-unauthorized", func(t *testing.T) {
+		mockCategoryRepo := new(CategoryRepository)
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Update(context.TODO(), &entities.Category{ID: 1, Name: "Makanan", Tag: "food"})
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	mockCategoryRepo := new(CategoryRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Category{ID: 1}, nil).Once()
+		mockCategoryRepo.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Delete(adminCtx, 1)
+
+		assert.NoError(t, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("category-is-not-exist", func(t *testing.T) {
+		mockCategoryRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Category{}, domain.ErrNotFound).Once()
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Delete(adminCtx, 1)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("error-forbidden", func(t *testing.T) {
+		mockCategoryRepo := new(CategoryRepository)
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Delete(domain.WithPrincipal(context.TODO(), domain.Principal{Subject: "1", AuthorID: 1}), 1)
+
+		assert.Equal(t, domain.ErrForbidden, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+	t.Run("error-unauthorized", func(t *testing.T) {
+		mockCategoryRepo := new(CategoryRepository)
+		u := category.NewUsecase(mockCategoryRepo, time.Second*2)
+
+		err := u.Delete(context.TODO(), 1)
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
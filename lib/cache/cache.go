@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Cache is a store of values expiring after a time to live, shared by the
+// caching decorators of the repositories
+type Cache interface {
+	// Get returns the value stored at key, ok is false when there is none or it has expired
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// GetMany returns the values stored at keys, the missing keys are absent from the map
+	GetMany(ctx context.Context, keys []string) (map[string][]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the values stored at keys, the missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+	// DeletePrefix removes every value whose key starts with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+	// Stats returns the counters of the cache since it was created
+	Stats() Stats
+}
+
+// Stats are the counters of a cache
+type Stats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// Evictions counts the values removed to make room for others, before they expired
+	Evictions uint64 `json:"evictions"`
+}
+
+// counters keeps the Stats of a cache, safe for concurrent use
+type counters struct {
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+func (c *counters) hit(n int) {
+	atomic.AddUint64(&c.hits, uint64(n))
+}
+
+func (c *counters) miss(n int) {
+	atomic.AddUint64(&c.misses, uint64(n))
+}
+
+func (c *counters) evict() {
+	atomic.AddUint64(&c.evictions, 1)
+}
+
+func (c *counters) Stats() Stats {
+	return Stats{
+		Hits:      atomic.LoadUint64(&c.hits),
+		Misses:    atomic.LoadUint64(&c.misses),
+		Evictions: atomic.LoadUint64(&c.evictions),
+	}
+}
@@ -0,0 +1,108 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/lib/cache"
+)
+
+// testCache will check the behavior every Cache implementation shares,
+// advance lets the time to live of the values run out
+func testCache(t *testing.T, c cache.Cache, advance func(d time.Duration)) {
+	ctx := context.TODO()
+
+	t.Run("get-set", func(t *testing.T) {
+		_, ok, err := c.Get(ctx, "article:1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, c.Set(ctx, "article:1", []byte("Makan Ayam"), time.Minute))
+		value, ok, err := c.Get(ctx, "article:1")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("Makan Ayam"), value)
+	})
+
+	t.Run("get-many", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, "author:1", []byte("Iman"), time.Minute))
+		require.NoError(t, c.Set(ctx, "author:2", []byte("Bxcodec"), time.Minute))
+
+		res, err := c.GetMany(ctx, []string{"author:1", "author:2", "author:3"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"author:1": []byte("Iman"), "author:2": []byte("Bxcodec")}, res)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, c.Delete(ctx, "author:1", "author:9"))
+		_, ok, err := c.Get(ctx, "author:1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, c.DeletePrefix(ctx, "article:"))
+		_, ok, err = c.Get(ctx, "article:1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, ok, err = c.Get(ctx, "author:2")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, "article:2", []byte("Makan Ikan"), 50*time.Millisecond))
+		advance(100 * time.Millisecond)
+
+		_, ok, err := c.Get(ctx, "article:2")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		stats := c.Stats()
+		assert.Equal(t, uint64(4), stats.Hits)
+		assert.Equal(t, uint64(5), stats.Misses)
+	})
+}
+
+func TestLRU(t *testing.T) {
+	testCache(t, cache.NewLRU(10), time.Sleep)
+
+	t.Run("evict", func(t *testing.T) {
+		ctx := context.TODO()
+		c := cache.NewLRU(2)
+		require.NoError(t, c.Set(ctx, "a", []byte("a"), time.Minute))
+		require.NoError(t, c.Set(ctx, "b", []byte("b"), time.Minute))
+		// a is now the most recently used, b goes first
+		_, _, _ = c.Get(ctx, "a")
+		require.NoError(t, c.Set(ctx, "c", []byte("c"), time.Minute))
+
+		_, ok, _ := c.Get(ctx, "b")
+		assert.False(t, ok)
+		_, ok, _ = c.Get(ctx, "a")
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), c.Stats().Evictions)
+	})
+}
+
+func TestRedis(t *testing.T) {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	defer server.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	testCache(t, cache.NewRedis(client), server.FastForward)
+
+	t.Run("error-failed", func(t *testing.T) {
+		server.Close()
+		_, _, err := cache.NewRedis(client).Get(context.TODO(), "article:1")
+		assert.Error(t, err)
+	})
+}
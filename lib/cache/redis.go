@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// scanCount is the number of keys DeletePrefix asks Redis to look at per SCAN call
+const scanCount = 100
+
+// redisCache keeps the values in Redis, so they are shared by every instance
+// of the service. Its Stats only count the lookups of this instance.
+type redisCache struct {
+	counters
+
+	client *redis.Client
+}
+
+// NewRedis will create a cache storing its values with client
+func NewRedis(client *redis.Client) Cache {
+	return &redisCache{client: client}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.WithContext(ctx).Get(key).Bytes()
+	if err == redis.Nil {
+		c.miss(1)
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	c.hit(1)
+	return value, true, nil
+}
+
+func (c *redisCache) GetMany(ctx context.Context, keys []string) (map[string][]byte, error) {
+	res := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return res, nil
+	}
+
+	values, err := c.client.WithContext(ctx).MGet(keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			res[keys[i]] = []byte(s)
+		}
+	}
+	c.hit(len(res))
+	c.miss(len(keys) - len(res))
+	return res, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.WithContext(ctx).Set(key, value, ttl).Err()
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.WithContext(ctx).Del(keys...).Err()
+}
+
+func (c *redisCache) DeletePrefix(ctx context.Context, prefix string) error {
+	client := c.client.WithContext(ctx)
+	iter := client.Scan(0, globEscape(prefix)+"*", scanCount).Iterator()
+
+	keys := make([]string, 0, scanCount)
+	for iter.Next() {
+		keys = append(keys, iter.Val())
+		if len(keys) == scanCount {
+			if err := client.Del(keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return c.Delete(ctx, keys...)
+}
+
+// globEscape will escape the characters of s having a meaning in a SCAN pattern
+func globEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
@@ -0,0 +1,151 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	// StatusOK is the status of a usable dependency, and of an instance whose dependencies all are
+	StatusOK = "ok"
+	// StatusFailing is the status of a dependency whose check failed or timed out,
+	// and of an instance with such a dependency
+	StatusFailing = "failing"
+	// StatusShuttingDown is the status of an instance stopping, which is no longer ready
+	StatusShuttingDown = "shutting_down"
+)
+
+// Check tells whether a dependency is usable, it returns why when it is not.
+// It should give up once ctx is done.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of the check of a dependency
+type Result struct {
+	Status    string  `json:"status"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+// Report is the outcome of the checks of every dependency, keyed by their name
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// OK will tell whether the instance is ready to serve requests
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Checker checks the dependencies registered to it, it is safe for concurrent use
+type Checker struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]Check
+
+	shuttingDown int32
+}
+
+// NewChecker will create a Checker giving each check timeout to complete
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout, checks: map[string]Check{}}
+}
+
+// Register will add the check of the dependency called name, replacing the one
+// registered with that name if any
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// SetShuttingDown will mark the instance as stopping, its reports are not ready from then on
+func (c *Checker) SetShuttingDown() {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+}
+
+// ShuttingDown will tell whether SetShuttingDown was called
+func (c *Checker) ShuttingDown() bool {
+	return atomic.LoadInt32(&c.shuttingDown) == 1
+}
+
+// Check will run every check at once and report their outcome. The instance is
+// ok when every check succeeds within the timeout and it is not shutting down.
+func (c *Checker) Check(ctx context.Context) Report {
+	if c.ShuttingDown() {
+		return Report{Status: StatusShuttingDown}
+	}
+
+	c.mu.RLock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		report = Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			res := c.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = res
+			if res.Status != StatusOK {
+				report.Status = StatusFailing
+			}
+		}(name, check)
+	}
+	wg.Wait()
+	return report
+}
+
+// run will run check, giving up after the timeout even when check ignores its context
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := Result{Status: StatusOK, LatencyMs: float64(time.Since(start)) / float64(time.Millisecond)}
+	if err != nil {
+		res.Status = StatusFailing
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// PingDB will return the Check of db, pinging it
+func PingDB(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// PingRedis will return the Check of the Redis server of client, pinging it
+func PingRedis(client *redis.Client) Check {
+	return func(ctx context.Context) error {
+		return client.WithContext(ctx).Ping().Err()
+	}
+}
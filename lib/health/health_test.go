@@ -0,0 +1,89 @@
+package health_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/lib/health"
+)
+
+func TestChecker(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+
+	t.Run("no-dependency", func(t *testing.T) {
+		c := health.NewChecker(time.Second)
+
+		report := c.Check(context.TODO())
+
+		assert.True(t, report.OK())
+		assert.Empty(t, report.Checks)
+	})
+	t.Run("all-ok", func(t *testing.T) {
+		c := health.NewChecker(time.Second)
+		c.Register("database", ok)
+		c.Register("cache", ok)
+
+		report := c.Check(context.TODO())
+
+		assert.True(t, report.OK())
+		assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, health.StatusOK, report.Checks["cache"].Status)
+	})
+	t.Run("failing", func(t *testing.T) {
+		c := health.NewChecker(time.Second)
+		c.Register("database", ok)
+		c.Register("cache", func(ctx context.Context) error { return errors.New("connection refused") })
+
+		report := c.Check(context.TODO())
+
+		assert.False(t, report.OK())
+		assert.Equal(t, health.StatusFailing, report.Status)
+		assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, health.Result{Status: health.StatusFailing, Error: "connection refused",
+			LatencyMs: report.Checks["cache"].LatencyMs}, report.Checks["cache"])
+	})
+	t.Run("timeout", func(t *testing.T) {
+		c := health.NewChecker(20 * time.Millisecond)
+		block := make(chan struct{})
+		defer close(block)
+		// a wedged dependency, ignoring the context
+		c.Register("database", func(ctx context.Context) error {
+			<-block
+			return nil
+		})
+
+		start := time.Now()
+		report := c.Check(context.TODO())
+
+		assert.Less(t, int64(time.Since(start)), int64(time.Second))
+		assert.Equal(t, health.StatusFailing, report.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"].Error)
+	})
+	t.Run("shutting-down", func(t *testing.T) {
+		c := health.NewChecker(time.Second)
+		c.Register("database", ok)
+		c.SetShuttingDown()
+
+		report := c.Check(context.TODO())
+
+		assert.True(t, c.ShuttingDown())
+		assert.False(t, report.OK())
+		assert.Equal(t, health.StatusShuttingDown, report.Status)
+	})
+}
+
+func TestPingDB(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	assert.NoError(t, health.PingDB(db)(context.TODO()))
+	require.NoError(t, db.Close())
+	assert.Error(t, health.PingDB(db)(context.TODO()))
+}
@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type contextKey struct{}
+
+// WithLogger will return a copy of ctx carrying the logger of the request
+// it belongs to, its entries are tagged with the fields of the request
+func WithLogger(ctx context.Context, l *logrus.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext will return the logger carried by ctx, or the standard logger
+// when there is none
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*logrus.Entry); ok {
+			return l
+		}
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
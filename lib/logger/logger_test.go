@@ -0,0 +1,33 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("request-logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := logrus.New()
+		l.Out = &buf
+		l.Formatter = &logrus.JSONFormatter{}
+		ctx := logger.WithLogger(context.TODO(), l.WithField("request_id", "abc"))
+
+		logger.FromContext(ctx).Error("connection refused")
+
+		assert.Contains(t, buf.String(), `"request_id":"abc"`)
+		assert.Contains(t, buf.String(), `"msg":"connection refused"`)
+	})
+
+	t.Run("standard-logger", func(t *testing.T) {
+		l := logger.FromContext(context.TODO())
+		assert.Equal(t, logrus.StandardLogger(), l.Logger)
+		assert.Empty(t, l.Data)
+	})
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tolbier/go-clean-arch/lib/cache"
+)
+
+// cacheStatsCollector exposes the counters of a cache.Cache
+type cacheStatsCollector struct {
+	cache cache.Cache
+
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+}
+
+// NewCacheStatsCollector will create a collector of the statistics of c, their
+// cache label is name
+func NewCacheStatsCollector(c cache.Cache, name string) prometheus.Collector {
+	labels := prometheus.Labels{"cache": name}
+	desc := func(fqName, help string) *prometheus.Desc {
+		return prometheus.NewDesc(fqName, help, nil, labels)
+	}
+	return &cacheStatsCollector{
+		cache:     c,
+		hits:      desc("cache_hits_total", "Number of lookups finding their value in the cache."),
+		misses:    desc("cache_misses_total", "Number of lookups missing the cache."),
+		evictions: desc("cache_evictions_total", "Number of values removed to make room for others, before they expired."),
+	}
+}
+
+func (c *cacheStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+}
+
+func (c *cacheStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+}
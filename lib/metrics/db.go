@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dbStatsCollector exposes the statistics of the connection pool of a sql.DB
+type dbStatsCollector struct {
+	db *sql.DB
+
+	maxOpen           *prometheus.Desc
+	open              *prometheus.Desc
+	inUse             *prometheus.Desc
+	idle              *prometheus.Desc
+	waitCount         *prometheus.Desc
+	waitDuration      *prometheus.Desc
+	maxIdleClosed     *prometheus.Desc
+	maxLifetimeClosed *prometheus.Desc
+}
+
+// NewDBStatsCollector will create a collector of the connection pool statistics
+// of db, their db label is name
+func NewDBStatsCollector(db *sql.DB, name string) prometheus.Collector {
+	labels := prometheus.Labels{"db": name}
+	desc := func(fqName, help string) *prometheus.Desc {
+		return prometheus.NewDesc(fqName, help, nil, labels)
+	}
+	return &dbStatsCollector{
+		db:                db,
+		maxOpen:           desc("db_max_open_connections", "Maximum number of open connections to the database."),
+		open:              desc("db_open_connections", "Number of established connections, in use and idle."),
+		inUse:             desc("db_in_use_connections", "Number of connections in use."),
+		idle:              desc("db_idle_connections", "Number of idle connections."),
+		waitCount:         desc("db_wait_count_total", "Number of connections waited for."),
+		waitDuration:      desc("db_wait_duration_seconds_total", "Time blocked waiting for a connection."),
+		maxIdleClosed:     desc("db_max_idle_closed_total", "Number of connections closed due to the maximum of idle connections."),
+		maxLifetimeClosed: desc("db_max_lifetime_closed_total", "Number of connections closed due to their maximum lifetime."),
+	}
+}
+
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.maxIdleClosed
+	ch <- c.maxLifetimeClosed
+}
+
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.maxIdleClosed, prometheus.CounterValue, float64(stats.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeClosed, prometheus.CounterValue, float64(stats.MaxLifetimeClosed))
+}
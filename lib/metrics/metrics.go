@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+// The layers of the calls recorded by Metrics
+const (
+	LayerUsecase    = "usecase"
+	LayerRepository = "repository"
+)
+
+// Metrics holds the collectors recording the requests and the calls through
+// the layers of the service
+type Metrics struct {
+	// HTTPDuration records the latency of the requests by method, route and status
+	HTTPDuration *prometheus.HistogramVec
+	// CallDuration records the latency of the calls by layer, component and method
+	CallDuration *prometheus.HistogramVec
+	// CallErrors counts the calls failed by layer, component, method and error code
+	CallErrors *prometheus.CounterVec
+}
+
+// New will create the collectors and register them with reg
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		HTTPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of the HTTP requests.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		CallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "call_duration_seconds",
+			Help:    "Latency of the usecase and repository calls.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"layer", "component", "method"}),
+		CallErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "call_errors_total",
+			Help: "Number of the usecase and repository calls failed, by error code.",
+		}, []string{"layer", "component", "method", "code"}),
+	}
+	for _, c := range []prometheus.Collector{m.HTTPDuration, m.CallDuration, m.CallErrors} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveCall will record a call of method started at start, and failed with
+// err when it is not nil
+func (m *Metrics) ObserveCall(layer, component, method string, start time.Time, err error) {
+	m.CallDuration.WithLabelValues(layer, component, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.CallErrors.WithLabelValues(layer, component, method, string(code(err))).Inc()
+	}
+}
+
+// code will return the code of err, the errors out of the domain are internal
+func code(err error) domain.Code {
+	var de *domain.Error
+	if errors.As(err, &de) {
+		return de.Code
+	}
+	return domain.CodeInternal
+}
@@ -0,0 +1,100 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/lib/cache"
+	"github.com/tolbier/go-clean-arch/lib/metrics"
+)
+
+// sampleCount will return the number of observations of the histogram name with the given labels
+func sampleCount(t *testing.T, reg prometheus.Gatherer, name string, labels map[string]string) uint64 {
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if labels[l.GetName()] != l.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetHistogram().GetSampleCount()
+		}
+	}
+	return 0
+}
+
+func TestObserveCall(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := metrics.New(reg)
+	require.NoError(t, err)
+
+	m.ObserveCall(metrics.LayerUsecase, "article", "GetByID", time.Now(), nil)
+	m.ObserveCall(metrics.LayerUsecase, "article", "GetByID", time.Now(), domain.ErrNotFound)
+	m.ObserveCall(metrics.LayerRepository, "article", "GetByID", time.Now(), errors.New("connection refused"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.CallErrors.WithLabelValues(metrics.LayerUsecase, "article", "GetByID", "not_found")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.CallErrors.WithLabelValues(metrics.LayerRepository, "article", "GetByID", "internal")))
+
+	assert.Equal(t, uint64(2), sampleCount(t, reg, "call_duration_seconds",
+		map[string]string{"layer": metrics.LayerUsecase, "component": "article", "method": "GetByID"}))
+
+	_, err = metrics.New(reg)
+	assert.Error(t, err, "the collectors are registered once")
+}
+
+func TestDBStatsCollector(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(5)
+
+	expected := `
+		# HELP db_max_open_connections Maximum number of open connections to the database.
+		# TYPE db_max_open_connections gauge
+		db_max_open_connections{db="mysql"} 5
+		# HELP db_wait_count_total Number of connections waited for.
+		# TYPE db_wait_count_total counter
+		db_wait_count_total{db="mysql"} 0
+	`
+	c := metrics.NewDBStatsCollector(db, "mysql")
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "db_max_open_connections", "db_wait_count_total"))
+}
+
+func TestCacheStatsCollector(t *testing.T) {
+	ctx := context.TODO()
+	c := cache.NewLRU(1)
+	require.NoError(t, c.Set(ctx, "a", []byte("a"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("b"), time.Minute))
+	_, _, _ = c.Get(ctx, "a")
+	_, _, _ = c.Get(ctx, "b")
+
+	expected := `
+		# HELP cache_evictions_total Number of values removed to make room for others, before they expired.
+		# TYPE cache_evictions_total counter
+		cache_evictions_total{cache="lru"} 1
+		# HELP cache_hits_total Number of lookups finding their value in the cache.
+		# TYPE cache_hits_total counter
+		cache_hits_total{cache="lru"} 1
+		# HELP cache_misses_total Number of lookups missing the cache.
+		# TYPE cache_misses_total counter
+		cache_misses_total{cache="lru"} 1
+	`
+	collector := metrics.NewCacheStatsCollector(c, "lru")
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
@@ -0,0 +1,115 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// lockName identifies the migration lock among the other locks of the database
+const lockName = "schema_migrations"
+
+// lockTimeoutSeconds is how long an instance waits for another one to finish migrating
+const lockTimeoutSeconds = 60
+
+// Dialect holds what differs between the supported databases
+type Dialect struct {
+	createTable   string
+	insertVersion string
+	deleteVersion string
+	// splitScript is set for the drivers executing a single statement per call
+	splitScript bool
+	lock        func(ctx context.Context, conn *sql.Conn) error
+	unlock      func(ctx context.Context, conn *sql.Conn) error
+}
+
+var (
+	// MySQL uses a named lock, its DDL statements are not transactional
+	MySQL = Dialect{
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version bigint NOT NULL PRIMARY KEY,
+  name varchar(255) NOT NULL,
+  applied_at datetime NOT NULL
+)`,
+		insertVersion: `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+		deleteVersion: `DELETE FROM schema_migrations WHERE version = ?`,
+		splitScript:   true,
+		lock: func(ctx context.Context, conn *sql.Conn) error {
+			var acquired sql.NullInt64
+			err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, lockName, lockTimeoutSeconds).Scan(&acquired)
+			if err != nil {
+				return err
+			}
+			if acquired.Int64 != 1 {
+				return errors.New("timeout waiting for another migration to finish")
+			}
+			return nil
+		},
+		unlock: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, `SELECT RELEASE_LOCK(?)`, lockName)
+			return err
+		},
+	}
+
+	// Postgres uses a session advisory lock
+	Postgres = Dialect{
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version BIGINT NOT NULL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  applied_at TIMESTAMP WITH TIME ZONE NOT NULL
+)`,
+		insertVersion: `INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+		deleteVersion: `DELETE FROM schema_migrations WHERE version = $1`,
+		lock: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext($1))`, lockName)
+			return err
+		},
+		unlock: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, lockName)
+			return err
+		},
+	}
+
+	// SQLite has no lock to take: the DDL is transactional and the database
+	// file admits one writer, an instance racing on the same version fails on
+	// the schema_migrations primary key and rolls its script back
+	SQLite = Dialect{
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version INTEGER NOT NULL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  applied_at DATETIME NOT NULL
+)`,
+		insertVersion: `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+		deleteVersion: `DELETE FROM schema_migrations WHERE version = ?`,
+		lock:          func(context.Context, *sql.Conn) error { return nil },
+		unlock:        func(context.Context, *sql.Conn) error { return nil },
+	}
+)
+
+// statements will return the statements to execute for script. A script is
+// split on the semicolons ending a line, so a statement must not hold one
+// at the end of a line inside a string literal.
+func (d Dialect) statements(script string) []string {
+	if !d.splitScript {
+		return []string{script}
+	}
+
+	res := make([]string, 0)
+	current := make([]string, 0)
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if len(current) == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+		current = append(current, line)
+		if strings.HasSuffix(trimmed, ";") {
+			res = append(res, strings.TrimSuffix(strings.TrimSpace(strings.Join(current, "\n")), ";"))
+			current = current[:0]
+		}
+	}
+	if stmt := strings.TrimSpace(strings.Join(current, "\n")); stmt != "" {
+		res = append(res, stmt)
+	}
+	return res
+}
@@ -0,0 +1,28 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatements(t *testing.T) {
+	script := `-- Baseline
+--
+
+CREATE TABLE article (
+  id int(11) NOT NULL,
+  content longtext NOT NULL
+);
+
+INSERT INTO article VALUES (1,'Curae; nibh');
+INSERT INTO article VALUES (2,'last')`
+
+	assert.Equal(t, []string{
+		"CREATE TABLE article (\n  id int(11) NOT NULL,\n  content longtext NOT NULL\n)",
+		"INSERT INTO article VALUES (1,'Curae; nibh')",
+		"INSERT INTO article VALUES (2,'last')",
+	}, MySQL.statements(script))
+
+	assert.Equal(t, []string{script}, Postgres.statements(script))
+}
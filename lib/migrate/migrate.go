@@ -0,0 +1,249 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoChange is returned by Down when there is no applied migration to revert
+var ErrNoChange = errors.New("no migration to revert")
+
+// Migration is one versioned change of the schema, read from the pair of files
+// <version>_<name>.up.sql and <version>_<name>.down.sql
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status tells whether a migration has been applied to the database
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load will read the migrations stored at the root of fsys, sorted by version
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	res := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", m.Version, m.Name)
+		}
+		res = append(res, *m)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+	return res, nil
+}
+
+// Migrator will apply and revert the migrations of one database
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// New will create a Migrator applying the migrations stored in fsys
+func New(db *sql.DB, dialect Dialect, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+// Up will apply every pending migration, in order, and return the applied ones
+func (m *Migrator) Up(ctx context.Context) (res []Migration, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			res = append(res, migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Down will revert the last applied migration
+func (m *Migrator) Down(ctx context.Context) (res Migration, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; ok {
+				res = m.migrations[i]
+				if strings.TrimSpace(res.Down) == "" {
+					return fmt.Errorf("migration %04d_%s has no down script", res.Version, res.Name)
+				}
+				return m.apply(ctx, conn, res, false)
+			}
+		}
+		return ErrNoChange
+	})
+	return
+}
+
+// Status will list every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) (res []Status, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			appliedAt, ok := applied[migration.Version]
+			res = append(res, Status{
+				Migration: migration,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+		return nil
+	})
+	return
+}
+
+// locked will run fn on a dedicated connection holding the migration lock,
+// so concurrent instances never apply the same migration twice
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	if err = m.dialect.lock(ctx, conn); err != nil {
+		return fmt.Errorf("acquire migration lock: %v", err)
+	}
+	defer func() {
+		errUnlock := m.dialect.unlock(context.Background(), conn)
+		if err == nil && errUnlock != nil {
+			err = fmt.Errorf("release migration lock: %v", errUnlock)
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return
+	}
+	return fn(conn)
+}
+
+// applied will return the applied versions and when they were applied
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		res[version] = appliedAt
+	}
+	return res, rows.Err()
+}
+
+// apply will run the up or down script of migration and record the change
+// in schema_migrations within the same transaction
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, up bool) (err error) {
+	script, record, args := migration.Down, m.dialect.deleteVersion, []interface{}{migration.Version}
+	if up {
+		script, record = migration.Up, m.dialect.insertVersion
+		args = append(args, migration.Name, time.Now().UTC())
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			err = fmt.Errorf("migration %04d_%s: %v", migration.Version, migration.Name, err)
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	for _, stmt := range m.dialect.statements(script) {
+		if _, err = tx.ExecContext(ctx, stmt); err != nil {
+			return
+		}
+	}
+	_, err = tx.ExecContext(ctx, record, args...)
+	return
+}
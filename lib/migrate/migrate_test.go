@@ -0,0 +1,128 @@
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/lib/migrate"
+)
+
+var scripts = fstest.MapFS{
+	"0001_baseline.up.sql":     {Data: []byte("CREATE TABLE author (id INTEGER PRIMARY KEY, name TEXT);")},
+	"0001_baseline.down.sql":   {Data: []byte("DROP TABLE author;")},
+	"0002_add_email.up.sql":    {Data: []byte("ALTER TABLE author ADD COLUMN email TEXT;")},
+	"0002_add_email.down.sql":  {Data: []byte("CREATE TABLE author_new (id INTEGER PRIMARY KEY, name TEXT);\nDROP TABLE author;\nALTER TABLE author_new RENAME TO author;")},
+	"README.md":                {Data: []byte("not a migration")},
+	"0003_not_a_script.up.txt": {Data: []byte("ignored")},
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	// every connection to :memory: is a new database
+	db.SetMaxOpenConns(1)
+	return db
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		list, err := migrate.Load(scripts)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, int64(1), list[0].Version)
+		assert.Equal(t, "baseline", list[0].Name)
+		assert.Equal(t, int64(2), list[1].Version)
+		assert.Equal(t, "add_email", list[1].Name)
+		assert.Contains(t, list[1].Down, "RENAME")
+	})
+
+	t.Run("error-missing-up", func(t *testing.T) {
+		_, err := migrate.Load(fstest.MapFS{
+			"0001_baseline.down.sql": {Data: []byte("DROP TABLE author;")},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("error-two-names", func(t *testing.T) {
+		_, err := migrate.Load(fstest.MapFS{
+			"0001_baseline.up.sql": {Data: []byte("CREATE TABLE author (id INTEGER);")},
+			"0001_other.down.sql":  {Data: []byte("DROP TABLE author;")},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	db := openDB(t)
+	defer db.Close()
+	ctx := context.TODO()
+
+	m, err := migrate.New(db, migrate.SQLite, scripts)
+	require.NoError(t, err)
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 2)
+	assert.False(t, status[0].Applied)
+	assert.False(t, status[1].Applied)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 2)
+	_, err = db.Exec(`INSERT INTO author (name, email) VALUES ('Iman', 'iman@example.com')`)
+	require.NoError(t, err)
+
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 0)
+
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, status[0].Applied)
+	assert.True(t, status[1].Applied)
+	assert.False(t, status[1].AppliedAt.IsZero())
+
+	reverted, err := m.Down(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), reverted.Version)
+	_, err = db.Exec(`INSERT INTO author (name, email) VALUES ('Iman', 'iman@example.com')`)
+	assert.Error(t, err)
+
+	reverted, err = m.Down(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reverted.Version)
+
+	_, err = m.Down(ctx)
+	assert.Equal(t, migrate.ErrNoChange, err)
+}
+
+func TestMigratorFailedMigrationIsRolledBack(t *testing.T) {
+	db := openDB(t)
+	defer db.Close()
+	ctx := context.TODO()
+
+	m, err := migrate.New(db, migrate.SQLite, fstest.MapFS{
+		"0001_baseline.up.sql": {Data: []byte("CREATE TABLE author (id INTEGER PRIMARY KEY);")},
+		"0002_broken.up.sql":   {Data: []byte("CREATE TABLE category (id INTEGER PRIMARY KEY);\nINSERT INTO missing VALUES (1);")},
+	})
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "0002_broken")
+	assert.Len(t, applied, 1)
+
+	_, err = db.Exec(`SELECT id FROM category`)
+	assert.Error(t, err)
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, status[0].Applied)
+	assert.False(t, status[1].Applied)
+}
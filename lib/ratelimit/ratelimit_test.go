@@ -0,0 +1,86 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/lib/ratelimit"
+)
+
+// testLimiter will check the behavior every Limiter implementation shares
+func testLimiter(t *testing.T, l ratelimit.Limiter) {
+	ctx := context.TODO()
+	limit := ratelimit.Limit{Rate: 10, Burst: 2}
+
+	t.Run("burst", func(t *testing.T) {
+		res, err := l.Allow(ctx, "ip:10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 1, res.Remaining)
+		assert.InDelta(t, 100*time.Millisecond, res.Reset, float64(20*time.Millisecond))
+
+		res, err = l.Allow(ctx, "ip:10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+
+		res, err = l.Allow(ctx, "ip:10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.True(t, res.RetryAfter > 0 && res.RetryAfter <= 100*time.Millisecond, res.RetryAfter.String())
+	})
+
+	t.Run("per-key", func(t *testing.T) {
+		res, err := l.Allow(ctx, "ip:10.0.0.2", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		time.Sleep(150 * time.Millisecond)
+		res, err := l.Allow(ctx, "ip:10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+}
+
+func TestMemory(t *testing.T) {
+	testLimiter(t, ratelimit.NewMemory())
+}
+
+func TestRedis(t *testing.T) {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	defer server.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	testLimiter(t, ratelimit.NewRedis(client))
+
+	t.Run("shared", func(t *testing.T) {
+		// another instance of the service sees the same buckets
+		other := ratelimit.NewRedis(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+		limit := ratelimit.Limit{Rate: 1, Burst: 1}
+		for i, allowed := range []bool{true, false} {
+			res, err := other.Allow(context.TODO(), "sub:1", limit)
+			require.NoError(t, err)
+			assert.Equal(t, allowed, res.Allowed, i)
+		}
+		res, err := ratelimit.NewRedis(client).Allow(context.TODO(), "sub:1", limit)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		server.Close()
+		_, err := ratelimit.NewRedis(client).Allow(context.TODO(), "ip:10.0.0.1", ratelimit.Limit{Rate: 1, Burst: 1})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// keyPrefix is prepended to the keys of the buckets in Redis
+const keyPrefix = "ratelimit:"
+
+// takeScript refills the bucket at KEYS[1] and takes a token from it, as the
+// memory limiter does. Its arguments are the rate, the burst and the current
+// time in milliseconds; it returns whether a token was taken and the tokens left.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(bucket[1])
+local last = tonumber(bucket[2])
+if tokens == nil or last == nil then
+  tokens = burst
+  last = now
+end
+if now > last then
+  tokens = math.min(burst, tokens + (now - last) * rate / 1000)
+end
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "last", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) * 1000 / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// redisLimiter keeps the buckets in Redis, so the limits hold across every
+// instance of the service. The instances must have their clocks in sync.
+type redisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedis will create a limiter keeping its buckets with client
+func NewRedis(client *redis.Client) Limiter {
+	return &redisLimiter{client: client}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	res, err := takeScript.Run(l.client.WithContext(ctx), []string{keyPrefix + key}, limit.Rate, limit.Burst, now).Result()
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, _ := res.([]interface{})
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected reply %v", res)
+	}
+	allowed, _ := values[0].(int64)
+	s, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Result{}, err
+	}
+	return result(allowed == 1, tokens, limit), nil
+}
@@ -1,30 +1,191 @@
 package repository
 
 import (
-    "encoding/base64"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
-const (
-	timeFormat = "2006-01-02T15:04:05.999Z07:00" // reduce precision from RFC3339Nano as date format
+// ErrInvalidCursor is returned when a cursor is malformed or was not signed with the cursor key
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+var (
+	// cursorEnd is the position past the newest item, where a descending listing starts
+	cursorEnd = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	cursorKeyMu sync.RWMutex
+	cursorKey   = randomKey()
 )
 
-// DecodeCursor will decode cursor from user for mysql
-func DecodeCursor(encodedTime string) (time.Time, error) {
-	byt, err := base64.StdEncoding.DecodeString(encodedTime)
+func randomKey() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// SetCursorKey will set the key signing the cursors. Without it a random key
+// is used, so the cursors are only valid until the process exits.
+func SetCursorKey(key []byte) {
+	cursorKeyMu.Lock()
+	defer cursorKeyMu.Unlock()
+	cursorKey = key
+}
+
+func sign(payload []byte) []byte {
+	cursorKeyMu.RLock()
+	defer cursorKeyMu.RUnlock()
+	mac := hmac.New(sha256.New, cursorKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Cursor is a position in a listing ordered by creation time, the id breaks
+// the ties between the items created at the same time
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"i"`
+	// Desc lists the newest items first
+	Desc bool `json:"d,omitempty"`
+	// Prev asks for the page before the position instead of the one after it
+	Prev bool `json:"p,omitempty"`
+}
+
+// hasPosition tells whether the cursor points past an item or at the start of the listing
+func (c Cursor) hasPosition() bool {
+	return c.ID != 0 || !c.CreatedAt.IsZero()
+}
+
+// Keyset will return the comparison selecting the items past the cursor and
+// the order to read them in: ">" and "ASC", or "<" and "DESC" when the
+// listing is read backward
+func (c Cursor) Keyset() (op string, order string) {
+	if c.Desc != c.Prev {
+		return "<", "DESC"
+	}
+	return ">", "ASC"
+}
+
+// Position will return the creation time and id the items are compared to
+func (c Cursor) Position() (time.Time, int64) {
+	if !c.hasPosition() && c.Desc {
+		return cursorEnd, 0
+	}
+	return c.CreatedAt, c.ID
+}
+
+// DecodeCursor will decode cursor from user, an empty cursor is the start of an ascending listing
+func DecodeCursor(encodedCursor string) (Cursor, error) {
+	if encodedCursor == "" {
+		return Cursor{}, nil
+	}
+
+	parts := strings.Split(encodedCursor, ".")
+	if len(parts) != 2 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
 	if err != nil {
-		return time.Time{}, err
+		return Cursor{}, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, sign(payload)) {
+		return Cursor{}, ErrInvalidCursor
 	}
 
-	timeString := string(byt)
-	t, err := time.Parse(timeFormat, timeString)
+	var c Cursor
+	if err = json.Unmarshal(payload, &c); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
 
-	return t, err
+// EncodeCursor will encode and sign cursor to user
+func EncodeCursor(c Cursor) string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload))
 }
 
-// EncodeCursor will encode cursor from mysql to user
-func EncodeCursor(t time.Time) string {
-	timeString := t.Format(timeFormat)
+// StartCursor will return the cursor of the first page of a listing
+func StartCursor(desc bool) string {
+	if !desc {
+		return ""
+	}
+	return EncodeCursor(Cursor{Desc: true})
+}
+
+// Paginate will turn the count items read past cursor, in the order given by
+// Keyset and at most num+1 of them, into a page: it returns how many of them
+// belong to the page, puts them in the listing order with swap, and returns
+// the cursors of the pages around it. position gives the creation time and
+// id of the i-th item.
+func Paginate(c Cursor, count int, num int64, swap func(i, j int),
+	position func(i int) (time.Time, int64)) (n int, nextCursor string, prevCursor string) {
+	n = count
+	more := int64(count) > num
+	if more {
+		n = int(num)
+	}
+	if n <= 0 {
+		// an empty page, or a num below 1 the callers should have refused
+		return 0, "", ""
+	}
+	if c.Prev {
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+	first := Cursor{Desc: c.Desc, Prev: true}
+	first.CreatedAt, first.ID = position(0)
+	last := Cursor{Desc: c.Desc}
+	last.CreatedAt, last.ID = position(n - 1)
+
+	// a page read backward was reached from the item following it, and
+	// a page read forward from the item preceding it, if any
+	if c.Prev {
+		nextCursor = EncodeCursor(last)
+		if more {
+			prevCursor = EncodeCursor(first)
+		}
+	} else {
+		if more {
+			nextCursor = EncodeCursor(last)
+		}
+		if c.hasPosition() {
+			prevCursor = EncodeCursor(first)
+		}
+	}
+	return
+}
+
+// DecodeOffsetCursor will decode the cursor of a ranked listing, where the
+// position in the ranking is the only way to resume
+func DecodeOffsetCursor(encodedOffset string) (int64, error) {
+	if encodedOffset == "" {
+		return 0, nil
+	}
+
+	byt, err := base64.StdEncoding.DecodeString(encodedOffset)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := strconv.ParseInt(string(byt), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid cursor")
+	}
+	return offset, nil
+}
 
-	return base64.StdEncoding.EncodeToString([]byte(timeString))
+// EncodeOffsetCursor will encode the position in a ranked listing to user
+func EncodeOffsetCursor(offset int64) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(offset, 10)))
 }
@@ -0,0 +1,131 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/lib/repository"
+)
+
+func TestCursor(t *testing.T) {
+	repository.SetCursorKey([]byte("secret"))
+	c := repository.Cursor{
+		CreatedAt: time.Date(2017, 5, 18, 13, 50, 19, 123456789, time.UTC),
+		ID:        3,
+		Desc:      true,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		res, err := repository.DecodeCursor(repository.EncodeCursor(c))
+		require.NoError(t, err)
+		assert.True(t, c.CreatedAt.Equal(res.CreatedAt))
+		assert.Equal(t, c.ID, res.ID)
+		assert.True(t, res.Desc)
+		assert.False(t, res.Prev)
+
+		res, err = repository.DecodeCursor("")
+		require.NoError(t, err)
+		assert.Equal(t, repository.Cursor{}, res)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		encoded := repository.EncodeCursor(c)
+		forged := repository.EncodeCursor(repository.Cursor{ID: 42})
+		// the payload of one cursor with the signature of another
+		_, err := repository.DecodeCursor(forged[:len(forged)-43] + encoded[len(encoded)-43:])
+		assert.Equal(t, repository.ErrInvalidCursor, err)
+
+		_, err = repository.DecodeCursor("not-a-cursor")
+		assert.Equal(t, repository.ErrInvalidCursor, err)
+
+		repository.SetCursorKey([]byte("rotated"))
+		defer repository.SetCursorKey([]byte("secret"))
+		_, err = repository.DecodeCursor(encoded)
+		assert.Equal(t, repository.ErrInvalidCursor, err)
+	})
+}
+
+func TestKeyset(t *testing.T) {
+	op, order := repository.Cursor{}.Keyset()
+	assert.Equal(t, ">", op)
+	assert.Equal(t, "ASC", order)
+
+	op, order = repository.Cursor{Desc: true}.Keyset()
+	assert.Equal(t, "<", op)
+	assert.Equal(t, "DESC", order)
+
+	op, order = repository.Cursor{Desc: true, Prev: true}.Keyset()
+	assert.Equal(t, ">", op)
+	assert.Equal(t, "ASC", order)
+
+	createdAt, _ := repository.Cursor{Desc: true}.Position()
+	assert.True(t, createdAt.After(time.Now()))
+}
+
+func TestPaginate(t *testing.T) {
+	now := time.Now()
+	position := func(ids []int64) func(i int) (time.Time, int64) {
+		return func(i int) (time.Time, int64) {
+			return now, ids[i]
+		}
+	}
+
+	t.Run("first-page", func(t *testing.T) {
+		ids := []int64{1, 2, 3}
+		n, nextCursor, prevCursor := repository.Paginate(repository.Cursor{}, len(ids), 2, func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		}, position(ids))
+
+		assert.Equal(t, 2, n)
+		assert.Empty(t, prevCursor)
+		next, err := repository.DecodeCursor(nextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), next.ID)
+		assert.False(t, next.Prev)
+	})
+
+	t.Run("prev-page", func(t *testing.T) {
+		// read backward from id 4
+		ids := []int64{3, 2, 1}
+		c := repository.Cursor{CreatedAt: now, ID: 4, Prev: true}
+		n, nextCursor, prevCursor := repository.Paginate(c, len(ids), 2, func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		}, position(ids))
+
+		assert.Equal(t, 2, n)
+		assert.Equal(t, []int64{2, 3}, ids[:n])
+		next, err := repository.DecodeCursor(nextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), next.ID)
+		prev, err := repository.DecodeCursor(prevCursor)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), prev.ID)
+		assert.True(t, prev.Prev)
+	})
+
+	t.Run("last-page", func(t *testing.T) {
+		ids := []int64{5}
+		c := repository.Cursor{CreatedAt: now, ID: 4}
+		n, nextCursor, prevCursor := repository.Paginate(c, len(ids), 2, func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		}, position(ids))
+
+		assert.Equal(t, 1, n)
+		assert.Empty(t, nextCursor)
+		assert.NotEmpty(t, prevCursor)
+	})
+
+	t.Run("negative-num", func(t *testing.T) {
+		var ids []int64
+		n, nextCursor, prevCursor := repository.Paginate(repository.Cursor{}, len(ids), -1, func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		}, position(ids))
+
+		assert.Equal(t, 0, n)
+		assert.Empty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+	})
+}
@@ -0,0 +1,39 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+)
+
+// Page will rank the matches scored by Score, the most relevant first as the
+// full-text indexes do, and return the page starting at the offset cursor
+func Page(matches []entities.ArticleMatch, cursor string, num int64) (res []entities.ArticleMatch, nextCursor string, err error) {
+	offset, err := repository.DecodeOffsetCursor(cursor)
+	if err != nil {
+		return nil, "", domain.ErrBadParamInput
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score == matches[j].Score {
+			return matches[i].ID > matches[j].ID
+		}
+		return matches[i].Score > matches[j].Score
+	})
+
+	if offset > int64(len(matches)) {
+		offset = int64(len(matches))
+	}
+	end := int64(len(matches))
+	if num > 0 && offset+num < end {
+		end = offset + num
+	}
+
+	res = matches[offset:end]
+	if num > 0 && int64(len(res)) == num {
+		nextCursor = repository.EncodeOffsetCursor(end)
+	}
+	return res, nextCursor, nil
+}
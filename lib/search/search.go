@@ -0,0 +1,128 @@
+package search
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// HighlightStart and HighlightEnd surround the matched terms of a snippet
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+
+	// snippetLength is the number of characters of text kept in a snippet
+	snippetLength = 160
+	// snippetLead is the number of characters kept before the first match
+	snippetLead = 40
+	// titleWeight makes a match in the title count more than one in the content
+	titleWeight = 3
+)
+
+var (
+	tags   = regexp.MustCompile(`<[^>]*>`)
+	spaces = regexp.MustCompile(`\s+`)
+)
+
+// Terms will split query in the distinct lower cased words it searches for
+func Terms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	seen := make(map[string]bool)
+	res := make([]string, 0, len(words))
+	for _, w := range words {
+		if !seen[w] {
+			seen[w] = true
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
+// Score will rank an article against the terms of a query, the article
+// matches when the score is above zero. It is used by the storages without
+// a full-text index of their own.
+func Score(title string, content string, terms []string) float64 {
+	title, content = strings.ToLower(title), strings.ToLower(PlainText(content))
+
+	score := 0
+	for _, term := range terms {
+		score += titleWeight*strings.Count(title, term) + strings.Count(content, term)
+	}
+	return float64(score)
+}
+
+// PlainText will strip the HTML markup of text and collapse its white spaces
+func PlainText(text string) string {
+	text = html.UnescapeString(tags.ReplaceAllString(text, " "))
+	return strings.TrimSpace(spaces.ReplaceAllString(text, " "))
+}
+
+// Snippet will cut the part of text around the first match of the query,
+// HTML escaped and with every match surrounded by HighlightStart and HighlightEnd
+func Snippet(text string, query string) string {
+	text = PlainText(text)
+	terms := Terms(query)
+
+	var matcher *regexp.Regexp
+	if len(terms) > 0 {
+		quoted := make([]string, len(terms))
+		for i, term := range terms {
+			quoted[i] = regexp.QuoteMeta(term)
+		}
+		matcher = regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+	}
+
+	// cut the window in runes so a multi-byte character is never split
+	start := 0
+	if matcher != nil {
+		if loc := matcher.FindStringIndex(text); loc != nil {
+			start = utf8.RuneCountInString(text[:loc[0]]) - snippetLead
+		}
+	}
+	runes := []rune(text)
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+	window := string(runes[start:end])
+
+	// do not show a word cut in half at the edges
+	if start > 0 {
+		if i := strings.IndexByte(window, ' '); i >= 0 {
+			window = window[i+1:]
+		}
+	}
+	if end < len(runes) {
+		if i := strings.LastIndexByte(window, ' '); i >= 0 {
+			window = window[:i]
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	last := 0
+	if matcher != nil {
+		for _, loc := range matcher.FindAllStringIndex(window, -1) {
+			b.WriteString(html.EscapeString(window[last:loc[0]]))
+			b.WriteString(HighlightStart)
+			b.WriteString(html.EscapeString(window[loc[0]:loc[1]]))
+			b.WriteString(HighlightEnd)
+			last = loc[1]
+		}
+	}
+	b.WriteString(html.EscapeString(window[last:]))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
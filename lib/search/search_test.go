@@ -0,0 +1,73 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/search"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"makan", "ayam"}, search.Terms("  Makan, ayam! MAKAN "))
+	assert.Empty(t, search.Terms(" ,.! "))
+}
+
+func TestScore(t *testing.T) {
+	terms := search.Terms("ayam")
+
+	assert.Zero(t, search.Score("Makan Ikan", "<p>ikan bakar</p>", terms))
+	inContent := search.Score("Makan Ikan", "<p>ikan dan ayam</p>", terms)
+	inTitle := search.Score("Makan Ayam", "<p>ikan bakar</p>", terms)
+	assert.True(t, inContent > 0)
+	assert.True(t, inTitle > inContent)
+}
+
+func TestSnippet(t *testing.T) {
+	t.Run("highlight", func(t *testing.T) {
+		res := search.Snippet("<p>Makan <b>ayam</b> & ikan</p>", "Ayam")
+		assert.Equal(t, "Makan <mark>ayam</mark> &amp; ikan", res)
+	})
+
+	t.Run("window", func(t *testing.T) {
+		text := strings.Repeat("lorem ipsum ", 30) + "ayam goreng " + strings.Repeat("dolor sit ", 30)
+		res := search.Snippet(text, "goreng")
+
+		assert.True(t, strings.HasPrefix(res, "…"))
+		assert.True(t, strings.HasSuffix(res, "…"))
+		assert.Contains(t, res, "ayam <mark>goreng</mark> dolor")
+		assert.True(t, len([]rune(res)) < 200)
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		res := search.Snippet("Makan ikan", "ayam")
+		assert.Equal(t, "Makan ikan", res)
+	})
+}
+
+func TestPage(t *testing.T) {
+	matches := []entities.ArticleMatch{
+		{Article: entities.Article{ID: 1}, Score: 1},
+		{Article: entities.Article{ID: 2}, Score: 3},
+		{Article: entities.Article{ID: 3}, Score: 1},
+	}
+
+	res, nextCursor, err := search.Page(matches, "", 2)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, int64(2), res[0].ID)
+	assert.Equal(t, int64(3), res[1].ID)
+
+	res, nextCursor, err = search.Page(matches, nextCursor, 2)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, int64(1), res[0].ID)
+	assert.Empty(t, nextCursor)
+
+	_, _, err = search.Page(matches, "bad cursor", 2)
+	assert.Equal(t, domain.ErrBadParamInput, err)
+}
@@ -0,0 +1,138 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel/api/core"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/key"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/trace/stdout"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/codes"
+
+	"github.com/tolbier/go-clean-arch/domain"
+)
+
+// TracerName is the name of the tracer of the service
+const TracerName = "github.com/tolbier/go-clean-arch"
+
+// Config represent the way the spans are exported
+type Config struct {
+	// Exporter is where the spans go: "stdout", "otlp", or "" not to record any
+	Exporter string
+	// OTLPAddress is the address of the OpenTelemetry collector receiving the spans, with "otlp"
+	OTLPAddress string
+	// SampleRatio is the fraction of the traces started by the service that are
+	// recorded, those continuing a sampled trace are always recorded
+	SampleRatio float64
+	// ServiceName is the service.name attribute of the spans
+	ServiceName string
+	// Writer is where the "stdout" exporter writes, os.Stdout when it is nil
+	Writer io.Writer
+}
+
+// Setup will install the global tracer provider exporting the spans as config
+// tells, and the W3C Trace Context propagator. The returned function flushes
+// the spans not exported yet, call it before exiting.
+func Setup(config Config) (shutdown func(), err error) {
+	global.SetPropagators(propagation.New(
+		propagation.WithExtractors(trace.TraceContext{}),
+		propagation.WithInjectors(trace.TraceContext{}),
+	))
+
+	var (
+		processor sdktrace.SpanProcessor
+		stop      = func() {}
+	)
+	switch config.Exporter {
+	case "":
+		global.SetTraceProvider(trace.NoopProvider{})
+		return stop, nil
+	case "stdout":
+		w := config.Writer
+		if w == nil {
+			w = os.Stdout
+		}
+		exporter, err := stdout.NewExporter(stdout.Options{Writer: w})
+		if err != nil {
+			return nil, err
+		}
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	case "otlp":
+		exporter, err := otlp.NewExporter(otlp.WithInsecure(), otlp.WithAddress(config.OTLPAddress))
+		if err != nil {
+			return nil, err
+		}
+		if processor, err = sdktrace.NewBatchSpanProcessor(exporter); err != nil {
+			return nil, err
+		}
+		stop = func() {
+			_ = exporter.Stop()
+		}
+	default:
+		return nil, fmt.Errorf("unknown exporter %q, expected one of: stdout, otlp, or empty to disable tracing", config.Exporter)
+	}
+
+	provider, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.ProbabilitySampler(config.SampleRatio)}),
+		sdktrace.WithResourceAttributes(key.String("service.name", config.ServiceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider.RegisterSpanProcessor(processor)
+	global.SetTraceProvider(provider)
+
+	return func() {
+		// unregistering the processor flushes it
+		provider.UnregisterSpanProcessor(processor)
+		stop()
+	}, nil
+}
+
+// Start will start a span named name, child of the span of ctx if any
+func Start(ctx context.Context, name string, attrs ...core.KeyValue) (context.Context, trace.Span) {
+	return global.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End will end span, recording err when it points to an error
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.SetStatus(statusCode(*err), (*err).Error())
+		span.SetAttributes(key.Bool("error", true))
+	}
+	span.End()
+}
+
+// statusCode will return the status of the span failed with err
+func statusCode(err error) codes.Code {
+	var de *domain.Error
+	if !errors.As(err, &de) {
+		return codes.Internal
+	}
+	switch de.Code {
+	case domain.CodeNotFound:
+		return codes.NotFound
+	case domain.CodeConflict:
+		return codes.AlreadyExists
+	case domain.CodeBadParamInput:
+		return codes.InvalidArgument
+	case domain.CodePreconditionFailed:
+		return codes.FailedPrecondition
+	case domain.CodeUnauthorized:
+		return codes.Unauthenticated
+	case domain.CodeForbidden:
+		return codes.PermissionDenied
+	case domain.CodeTooManyRequests:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
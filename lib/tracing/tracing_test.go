@@ -0,0 +1,155 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// exportedSpan holds the fields of the spans written by the stdout exporter checked by the tests
+type exportedSpan struct {
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	ParentSpanID string
+	Name         string
+	StatusCode   codes.Code
+	Attributes   []struct {
+		Key   string
+		Value struct {
+			Value interface{}
+		}
+	}
+}
+
+func (s exportedSpan) attribute(key string) interface{} {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value.Value
+		}
+	}
+	return nil
+}
+
+// setupStdout will record the spans until the returned function is called, which returns them
+func setupStdout(t *testing.T) func() []exportedSpan {
+	var buf bytes.Buffer
+	shutdown, err := tracing.Setup(tracing.Config{Exporter: "stdout", SampleRatio: 1, ServiceName: "test", Writer: &buf})
+	require.NoError(t, err)
+	return func() []exportedSpan {
+		shutdown()
+		var spans []exportedSpan
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var s exportedSpan
+			require.NoError(t, dec.Decode(&s))
+			spans = append(spans, s)
+		}
+		return spans
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("unknown-exporter", func(t *testing.T) {
+		_, err := tracing.Setup(tracing.Config{Exporter: "zipkin"})
+		assert.Error(t, err)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		shutdown, err := tracing.Setup(tracing.Config{})
+		require.NoError(t, err)
+		defer shutdown()
+
+		_, span := tracing.Start(context.TODO(), "noop")
+		defer span.End()
+		assert.False(t, span.SpanContext().IsValid())
+	})
+}
+
+func TestStartEnd(t *testing.T) {
+	stop := setupStdout(t)
+
+	ctx, parent := tracing.Start(context.TODO(), "parent")
+	_, child := tracing.Start(ctx, "child")
+	var err error = domain.ErrNotFound
+	tracing.End(child, &err)
+	var noErr error
+	tracing.End(parent, &noErr)
+
+	spans := stop()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, codes.NotFound, spans[0].StatusCode)
+	assert.Equal(t, true, spans[0].attribute("error"))
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, codes.OK, spans[1].StatusCode)
+	assert.Nil(t, spans[1].attribute("error"))
+	assert.Equal(t, spans[1].SpanContext.TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].ParentSpanID)
+}
+
+func TestOpen(t *testing.T) {
+	stop := setupStdout(t)
+
+	db, err := tracing.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, parent := tracing.Start(context.TODO(), "parent")
+	_, err = db.ExecContext(ctx, "CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO t (name) VALUES (?)", "a")
+	require.NoError(t, err)
+	stmt, err := db.PrepareContext(ctx, "SELECT name FROM t WHERE id = ?")
+	require.NoError(t, err)
+	var name string
+	require.NoError(t, stmt.QueryRowContext(ctx, 1).Scan(&name))
+	assert.Equal(t, "a", name)
+	require.NoError(t, stmt.Close())
+	_, err = db.ExecContext(ctx, "DELETE FROM nope")
+	require.Error(t, err)
+	parent.End()
+
+	spans := stop()
+	require.Len(t, spans, 5)
+	parentSpan := spans[4]
+	for i, want := range []struct {
+		name      string
+		statement string
+		status    codes.Code
+	}{
+		{"sqlite CREATE", "CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT)", codes.OK},
+		{"sqlite INSERT", "INSERT INTO t (name) VALUES (?)", codes.OK},
+		{"sqlite SELECT", "SELECT name FROM t WHERE id = ?", codes.OK},
+		{"sqlite DELETE", "DELETE FROM nope", codes.Internal},
+	} {
+		assert.Equal(t, want.name, spans[i].Name)
+		assert.Equal(t, "sqlite", spans[i].attribute("db.system"))
+		assert.Equal(t, want.statement, spans[i].attribute("db.statement"))
+		assert.Equal(t, want.status, spans[i].StatusCode)
+		assert.Equal(t, parentSpan.SpanContext.SpanID, spans[i].ParentSpanID)
+	}
+}
+
+func TestEndUnknownError(t *testing.T) {
+	stop := setupStdout(t)
+
+	_, span := tracing.Start(context.TODO(), "span")
+	err := errors.New("boom")
+	tracing.End(span, &err)
+
+	spans := stop()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Internal, spans[0].StatusCode)
+}
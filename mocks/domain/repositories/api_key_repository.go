@@ -0,0 +1,110 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	entities "github.com/tolbier/go-clean-arch/domain/entities"
+
+	time "time"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx
+func (_m *APIKeyRepository) Fetch(ctx context.Context) ([]entities.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entities.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context) []entities.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *APIKeyRepository) GetByID(ctx context.Context, id int64) (entities.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 entities.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entities.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entities.APIKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByPrefix provides a mock function with given fields: ctx, prefix
+func (_m *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (entities.APIKey, error) {
+	ret := _m.Called(ctx, prefix)
+
+	var r0 entities.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) entities.APIKey); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(entities.APIKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, id, revokedAt
+func (_m *APIKeyRepository) Revoke(ctx context.Context, id int64, revokedAt time.Time) error {
+	ret := _m.Called(ctx, id, revokedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store provides a mock function with given fields: ctx, k
+func (_m *APIKeyRepository) Store(ctx context.Context, k *entities.APIKey) error {
+	ret := _m.Called(ctx, k)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.APIKey) error); ok {
+		r0 = rf(ctx, k)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
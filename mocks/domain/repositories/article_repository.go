@@ -14,13 +14,13 @@ type ArticleRepository struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *ArticleRepository) Delete(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// Delete provides a mock function with given fields: ctx, id, version
+func (_m *ArticleRepository) Delete(ctx context.Context, id int64, version int64) error {
+	ret := _m.Called(ctx, id, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -29,7 +29,7 @@ func (_m *ArticleRepository) Delete(ctx context.Context, id int64) error {
 }
 
 // Fetch provides a mock function with given fields: ctx, cursor, num
-func (_m *ArticleRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, error) {
+func (_m *ArticleRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, string, error) {
 	ret := _m.Called(ctx, cursor, num)
 
 	var r0 []entities.Article
@@ -48,14 +48,95 @@ func (_m *ArticleRepository) Fetch(ctx context.Context, cursor string, num int64
 		r1 = ret.Get(1).(string)
 	}
 
-	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, string, int64) error); ok {
+	var r2 string
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64) string); ok {
 		r2 = rf(ctx, cursor, num)
 	} else {
-		r2 = ret.Error(2)
+		r2 = ret.Get(2).(string)
 	}
 
-	return r0, r1, r2
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, string, int64) error); ok {
+		r3 = rf(ctx, cursor, num)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// FetchByAuthor provides a mock function with given fields: ctx, authorID, cursor, num
+func (_m *ArticleRepository) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) ([]entities.Article, string, string, error) {
+	ret := _m.Called(ctx, authorID, cursor, num)
+
+	var r0 []entities.Article
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) []entities.Article); ok {
+		r0 = rf(ctx, authorID, cursor, num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Article)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int64) string); ok {
+		r1 = rf(ctx, authorID, cursor, num)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(context.Context, int64, string, int64) string); ok {
+		r2 = rf(ctx, authorID, cursor, num)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, int64, string, int64) error); ok {
+		r3 = rf(ctx, authorID, cursor, num)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// FetchByCategory provides a mock function with given fields: ctx, tag, cursor, num
+func (_m *ArticleRepository) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) ([]entities.Article, string, string, error) {
+	ret := _m.Called(ctx, tag, cursor, num)
+
+	var r0 []entities.Article
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []entities.Article); ok {
+		r0 = rf(ctx, tag, cursor, num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Article)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) string); ok {
+		r1 = rf(ctx, tag, cursor, num)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64) string); ok {
+		r2 = rf(ctx, tag, cursor, num)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, string, string, int64) error); ok {
+		r3 = rf(ctx, tag, cursor, num)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
 }
 
 // GetByID provides a mock function with given fields: ctx, id
@@ -100,6 +181,36 @@ func (_m *ArticleRepository) GetByTitle(ctx context.Context, title string) (enti
 	return r0, r1
 }
 
+// Search provides a mock function with given fields: ctx, query, cursor, num
+func (_m *ArticleRepository) Search(ctx context.Context, query string, cursor string, num int64) ([]entities.ArticleMatch, string, error) {
+	ret := _m.Called(ctx, query, cursor, num)
+
+	var r0 []entities.ArticleMatch
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []entities.ArticleMatch); ok {
+		r0 = rf(ctx, query, cursor, num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ArticleMatch)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) string); ok {
+		r1 = rf(ctx, query, cursor, num)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64) error); ok {
+		r2 = rf(ctx, query, cursor, num)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Store provides a mock function with given fields: ctx, a
 func (_m *ArticleRepository) Store(ctx context.Context, a *entities.Article) error {
 	ret := _m.Called(ctx, a)
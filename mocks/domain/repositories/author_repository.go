@@ -14,6 +14,50 @@ type AuthorRepository struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: ctx, id, reassignTo
+func (_m *AuthorRepository) Delete(ctx context.Context, id int64, reassignTo int64) error {
+	ret := _m.Called(ctx, id, reassignTo)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, reassignTo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Fetch provides a mock function with given fields: ctx, cursor, num
+func (_m *AuthorRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Author, string, error) {
+	ret := _m.Called(ctx, cursor, num)
+
+	var r0 []entities.Author
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []entities.Author); ok {
+		r0 = rf(ctx, cursor, num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Author)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) string); ok {
+		r1 = rf(ctx, cursor, num)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64) error); ok {
+		r2 = rf(ctx, cursor, num)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *AuthorRepository) GetByID(ctx context.Context, id int64) (entities.Author, error) {
 	ret := _m.Called(ctx, id)
@@ -34,3 +78,54 @@ func (_m *AuthorRepository) GetByID(ctx context.Context, id int64) (entities.Aut
 
 	return r0, r1
 }
+
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *AuthorRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]entities.Author, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 map[int64]entities.Author
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]entities.Author); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]entities.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, a
+func (_m *AuthorRepository) Store(ctx context.Context, a *entities.Author) error {
+	ret := _m.Called(ctx, a)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Author) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, a
+func (_m *AuthorRepository) Update(ctx context.Context, a *entities.Author) error {
+	ret := _m.Called(ctx, a)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Author) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
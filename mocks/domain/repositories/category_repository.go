@@ -0,0 +1,173 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	entities "github.com/tolbier/go-clean-arch/domain/entities"
+)
+
+// CategoryRepository is an autogenerated mock type for the CategoryRepository type
+type CategoryRepository struct {
+	mock.Mock
+}
+
+// Attach provides a mock function with given fields: ctx, articleID, categoryID
+func (_m *CategoryRepository) Attach(ctx context.Context, articleID int64, categoryID int64) error {
+	ret := _m.Called(ctx, articleID, categoryID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, articleID, categoryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Detach provides a mock function with given fields: ctx, articleID, categoryID
+func (_m *CategoryRepository) Detach(ctx context.Context, articleID int64, categoryID int64) error {
+	ret := _m.Called(ctx, articleID, categoryID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, articleID, categoryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Fetch provides a mock function with given fields: ctx
+func (_m *CategoryRepository) Fetch(ctx context.Context) ([]entities.Category, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entities.Category
+	if rf, ok := ret.Get(0).(func(context.Context) []entities.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByArticleIDs provides a mock function with given fields: ctx, articleIDs
+func (_m *CategoryRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]entities.Category, error) {
+	ret := _m.Called(ctx, articleIDs)
+
+	var r0 map[int64][]entities.Category
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]entities.Category); ok {
+		r0 = rf(ctx, articleIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]entities.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, articleIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) GetByID(ctx context.Context, id int64) (entities.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 entities.Category
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entities.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entities.Category)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTag provides a mock function with given fields: ctx, tag
+func (_m *CategoryRepository) GetByTag(ctx context.Context, tag string) (entities.Category, error) {
+	ret := _m.Called(ctx, tag)
+
+	var r0 entities.Category
+	if rf, ok := ret.Get(0).(func(context.Context, string) entities.Category); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		r0 = ret.Get(0).(entities.Category)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, c
+func (_m *CategoryRepository) Store(ctx context.Context, c *entities.Category) error {
+	ret := _m.Called(ctx, c)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Category) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, c
+func (_m *CategoryRepository) Update(ctx context.Context, c *entities.Category) error {
+	ret := _m.Called(ctx, c)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Category) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
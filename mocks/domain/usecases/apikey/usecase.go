@@ -0,0 +1,122 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	entities "github.com/tolbier/go-clean-arch/domain/entities"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, key
+func (_m *Usecase) Authenticate(ctx context.Context, key string) (entities.APIKey, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 entities.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) entities.APIKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(entities.APIKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Fetch provides a mock function with given fields: ctx
+func (_m *Usecase) Fetch(ctx context.Context) ([]entities.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entities.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context) []entities.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Issue provides a mock function with given fields: ctx, k
+func (_m *Usecase) Issue(ctx context.Context, k *entities.APIKey) (string, error) {
+	ret := _m.Called(ctx, k)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.APIKey) string); ok {
+		r0 = rf(ctx, k)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.APIKey) error); ok {
+		r1 = rf(ctx, k)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, id
+func (_m *Usecase) Revoke(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Rotate provides a mock function with given fields: ctx, id
+func (_m *Usecase) Rotate(ctx context.Context, id int64) (entities.APIKey, string, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 entities.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entities.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entities.APIKey)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64) string); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
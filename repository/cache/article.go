@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libcache "github.com/tolbier/go-clean-arch/lib/cache"
+)
+
+// articlePrefix starts the keys of the cached articles
+const articlePrefix = "article:"
+
+func articleKey(id int64) string {
+	return fmt.Sprintf("%s%d", articlePrefix, id)
+}
+
+// cachedArticleRepository caches the articles read by id. The listings, the
+// search and GetByTitle, which checks titles are unique, always read the storage.
+type cachedArticleRepository struct {
+	repositories.ArticleRepository
+	store store
+}
+
+// NewCachedArticleRepository will create an object that represent the article.Repository interface,
+// caching the articles of next for ttl and the ids without article for negativeTTL
+func NewCachedArticleRepository(next repositories.ArticleRepository, c libcache.Cache, ttl time.Duration,
+	negativeTTL time.Duration) repositories.ArticleRepository {
+	return &cachedArticleRepository{
+		ArticleRepository: next,
+		store:             store{cache: c, ttl: ttl, negativeTTL: negativeTTL},
+	}
+}
+
+func (m *cachedArticleRepository) GetByID(ctx context.Context, id int64) (res entities.Article, err error) {
+	key := articleKey(id)
+	if ok, err := m.store.load(ctx, key, &res); ok {
+		return res, err
+	}
+
+	res, err = m.ArticleRepository.GetByID(ctx, id)
+	m.store.save(ctx, key, res, err)
+	return
+}
+
+func (m *cachedArticleRepository) Store(ctx context.Context, a *entities.Article) error {
+	err := m.ArticleRepository.Store(ctx, a)
+	if err == nil {
+		// the id may have been cached as not found
+		m.store.invalidate(ctx, articleKey(a.ID))
+	}
+	return err
+}
+
+func (m *cachedArticleRepository) Update(ctx context.Context, ar *entities.Article) error {
+	err := m.ArticleRepository.Update(ctx, ar)
+	m.store.invalidate(ctx, articleKey(ar.ID))
+	return err
+}
+
+func (m *cachedArticleRepository) Delete(ctx context.Context, id int64, version int64) error {
+	err := m.ArticleRepository.Delete(ctx, id, version)
+	m.store.invalidate(ctx, articleKey(id))
+	return err
+}
@@ -0,0 +1,113 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	libcache "github.com/tolbier/go-clean-arch/lib/cache"
+	mocks "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+	"github.com/tolbier/go-clean-arch/repository/cache"
+)
+
+func TestArticleGetByID(t *testing.T) {
+	mockArticle := entities.Article{ID: 1, Title: "Makan Ayam", Author: entities.Author{ID: 1}, Version: 2,
+		CreatedAt: time.Date(2017, 5, 18, 13, 50, 19, 0, time.UTC)}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(mockArticle, nil).Once()
+		c := libcache.NewLRU(10)
+		a := cache.NewCachedArticleRepository(mockArticleRepo, c, time.Minute, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			res, err := a.GetByID(context.TODO(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, mockArticle, res)
+		}
+		assert.Equal(t, libcache.Stats{Hits: 1, Misses: 1}, c.Stats())
+		mockArticleRepo.AssertExpectations(t)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(2)).Return(entities.Article{}, domain.ErrNotFound).Once()
+		a := cache.NewCachedArticleRepository(mockArticleRepo, libcache.NewLRU(10), time.Minute, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			_, err := a.GetByID(context.TODO(), 2)
+			assert.Equal(t, domain.ErrNotFound, err)
+		}
+		mockArticleRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(3)).Return(entities.Article{}, errors.New("Unexpected Error")).Twice()
+		a := cache.NewCachedArticleRepository(mockArticleRepo, libcache.NewLRU(10), time.Minute, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			_, err := a.GetByID(context.TODO(), 3)
+			assert.Error(t, err)
+		}
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
+
+func TestArticleInvalidate(t *testing.T) {
+	mockArticle := entities.Article{ID: 1, Title: "Makan Ayam", Version: 1}
+
+	t.Run("update", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(mockArticle, nil).Twice()
+		mockArticleRepo.On("Update", mock.Anything, &mockArticle).Return(nil).Once()
+		a := cache.NewCachedArticleRepository(mockArticleRepo, libcache.NewLRU(10), time.Minute, time.Minute)
+
+		_, err := a.GetByID(context.TODO(), 1)
+		require.NoError(t, err)
+		require.NoError(t, a.Update(context.TODO(), &mockArticle))
+		_, err = a.GetByID(context.TODO(), 1)
+		require.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+
+	t.Run("store", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Article{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*entities.Article")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*entities.Article).ID = 1
+		}).Once()
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(mockArticle, nil).Once()
+		a := cache.NewCachedArticleRepository(mockArticleRepo, libcache.NewLRU(10), time.Minute, time.Minute)
+
+		_, err := a.GetByID(context.TODO(), 1)
+		assert.Equal(t, domain.ErrNotFound, err)
+		require.NoError(t, a.Store(context.TODO(), &entities.Article{Title: "Makan Ayam"}))
+		res, err := a.GetByID(context.TODO(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, mockArticle, res)
+		mockArticleRepo.AssertExpectations(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ArticleRepository)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("Delete", mock.Anything, int64(1), int64(1)).Return(nil).Once()
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Article{}, domain.ErrNotFound).Once()
+		a := cache.NewCachedArticleRepository(mockArticleRepo, libcache.NewLRU(10), time.Minute, time.Minute)
+
+		_, err := a.GetByID(context.TODO(), 1)
+		require.NoError(t, err)
+		require.NoError(t, a.Delete(context.TODO(), 1, 1))
+		_, err = a.GetByID(context.TODO(), 1)
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libcache "github.com/tolbier/go-clean-arch/lib/cache"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+func authorKey(id int64) string {
+	return fmt.Sprintf("author:%d", id)
+}
+
+// cachedAuthorRepository caches the authors read by id, the listing always reads the storage
+type cachedAuthorRepository struct {
+	repositories.AuthorRepository
+	store store
+}
+
+// NewCachedAuthorRepository will create an implementation of author.Repository,
+// caching the authors of next for ttl and the ids without author for negativeTTL
+func NewCachedAuthorRepository(next repositories.AuthorRepository, c libcache.Cache, ttl time.Duration,
+	negativeTTL time.Duration) repositories.AuthorRepository {
+	return &cachedAuthorRepository{
+		AuthorRepository: next,
+		store:            store{cache: c, ttl: ttl, negativeTTL: negativeTTL},
+	}
+}
+
+func (m *cachedAuthorRepository) GetByID(ctx context.Context, id int64) (res entities.Author, err error) {
+	key := authorKey(id)
+	if ok, err := m.store.load(ctx, key, &res); ok {
+		return res, err
+	}
+
+	res, err = m.AuthorRepository.GetByID(ctx, id)
+	m.store.save(ctx, key, res, err)
+	return
+}
+
+func (m *cachedAuthorRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]entities.Author, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = authorKey(id)
+	}
+	cached, err := m.store.cache.GetMany(ctx, keys)
+	if err != nil {
+		logger.FromContext(ctx).Warnf("cache get %v: %v", keys, err)
+	}
+
+	res := make(map[int64]entities.Author, len(ids))
+	missing := make([]int64, 0, len(ids))
+	for i, id := range ids {
+		value, ok := cached[keys[i]]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+
+		var a entities.Author
+		ok, err := m.store.decode(ctx, keys[i], value, &a)
+		switch {
+		case !ok:
+			missing = append(missing, id)
+		case err == nil:
+			res[id] = a
+		}
+	}
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	found, err := m.AuthorRepository.GetByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range missing {
+		a, ok := found[id]
+		if !ok {
+			m.store.save(ctx, authorKey(id), nil, domain.ErrNotFound)
+			continue
+		}
+		res[id] = a
+		m.store.save(ctx, authorKey(id), a, nil)
+	}
+	return res, nil
+}
+
+func (m *cachedAuthorRepository) Store(ctx context.Context, a *entities.Author) error {
+	err := m.AuthorRepository.Store(ctx, a)
+	if err == nil {
+		// the id may have been cached as not found
+		m.store.invalidate(ctx, authorKey(a.ID))
+	}
+	return err
+}
+
+func (m *cachedAuthorRepository) Update(ctx context.Context, a *entities.Author) error {
+	err := m.AuthorRepository.Update(ctx, a)
+	m.store.invalidate(ctx, authorKey(a.ID))
+	return err
+}
+
+func (m *cachedAuthorRepository) Delete(ctx context.Context, id int64, reassignTo int64) error {
+	err := m.AuthorRepository.Delete(ctx, id, reassignTo)
+	m.store.invalidate(ctx, authorKey(id))
+	if reassignTo != 0 {
+		// the reassigned articles are not known here, drop every cached article
+		if errCache := m.store.cache.DeletePrefix(ctx, articlePrefix); errCache != nil {
+			logger.FromContext(ctx).Errorf("cache delete %s*: %v", articlePrefix, errCache)
+		}
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	libcache "github.com/tolbier/go-clean-arch/lib/cache"
+	mocks "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+	"github.com/tolbier/go-clean-arch/repository/cache"
+)
+
+func TestAuthorGetByID(t *testing.T) {
+	mockAuthor := entities.Author{ID: 1, Name: "Iman Tumorang"}
+	mockAuthorRepo := new(mocks.AuthorRepository)
+	mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+	mockAuthorRepo.On("Update", mock.Anything, &mockAuthor).Return(nil).Once()
+	mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthor, nil).Once()
+	a := cache.NewCachedAuthorRepository(mockAuthorRepo, libcache.NewLRU(10), time.Minute, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		res, err := a.GetByID(context.TODO(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, mockAuthor, res)
+	}
+	require.NoError(t, a.Update(context.TODO(), &mockAuthor))
+	_, err := a.GetByID(context.TODO(), 1)
+	require.NoError(t, err)
+	mockAuthorRepo.AssertExpectations(t)
+}
+
+func TestAuthorGetByIDs(t *testing.T) {
+	mockAuthors := map[int64]entities.Author{
+		1: {ID: 1, Name: "Iman Tumorang"},
+		2: {ID: 2, Name: "Bxcodec"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepo := new(mocks.AuthorRepository)
+		mockAuthorRepo.On("GetByID", mock.Anything, int64(1)).Return(mockAuthors[1], nil).Once()
+		// only the authors missing from the cache are read, 3 does not exist
+		mockAuthorRepo.On("GetByIDs", mock.Anything, []int64{2, 3}).Return(map[int64]entities.Author{2: mockAuthors[2]}, nil).Once()
+		c := libcache.NewLRU(10)
+		a := cache.NewCachedAuthorRepository(mockAuthorRepo, c, time.Minute, time.Minute)
+
+		_, err := a.GetByID(context.TODO(), 1)
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			res, err := a.GetByIDs(context.TODO(), []int64{1, 2, 3})
+			require.NoError(t, err)
+			assert.Equal(t, mockAuthors, res)
+		}
+
+		_, err = a.GetByID(context.TODO(), 3)
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Equal(t, libcache.Stats{Hits: 5, Misses: 3}, c.Stats())
+		mockAuthorRepo.AssertExpectations(t)
+	})
+
+	t.Run("no-negative-caching", func(t *testing.T) {
+		mockAuthorRepo := new(mocks.AuthorRepository)
+		mockAuthorRepo.On("GetByIDs", mock.Anything, []int64{3}).Return(map[int64]entities.Author{}, nil).Twice()
+		a := cache.NewCachedAuthorRepository(mockAuthorRepo, libcache.NewLRU(10), time.Minute, 0)
+
+		for i := 0; i < 2; i++ {
+			res, err := a.GetByIDs(context.TODO(), []int64{3})
+			require.NoError(t, err)
+			assert.Empty(t, res)
+		}
+		mockAuthorRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthorDelete(t *testing.T) {
+	mockArticle := entities.Article{ID: 1, Title: "Hello", Author: entities.Author{ID: 1}}
+	c := libcache.NewLRU(10)
+	mockArticleRepo := new(mocks.ArticleRepository)
+	mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(mockArticle, nil).Twice()
+	mockAuthorRepo := new(mocks.AuthorRepository)
+	mockAuthorRepo.On("Delete", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+	ar := cache.NewCachedArticleRepository(mockArticleRepo, c, time.Minute, time.Minute)
+	a := cache.NewCachedAuthorRepository(mockAuthorRepo, c, time.Minute, time.Minute)
+
+	_, err := ar.GetByID(context.TODO(), 1)
+	require.NoError(t, err)
+	require.NoError(t, a.Delete(context.TODO(), 1, 2))
+	// the article moved to the other author is read again
+	_, err = ar.GetByID(context.TODO(), 1)
+	require.NoError(t, err)
+	mockArticleRepo.AssertExpectations(t)
+	mockAuthorRepo.AssertExpectations(t)
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	libcache "github.com/tolbier/go-clean-arch/lib/cache"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+// notFound is the value cached for the ids the storage has no entity for
+var notFound = []byte("\x00not-found")
+
+// store reads and writes the entities of a decorator in the cache. The cache
+// is never required: its errors are logged and the storage is used instead.
+type store struct {
+	cache       libcache.Cache
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// load will decode the value cached at key into dst. It returns false when
+// nothing is cached, and domain.ErrNotFound when the entity is known not to exist.
+func (s store) load(ctx context.Context, key string, dst interface{}) (bool, error) {
+	value, ok, err := s.cache.Get(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx).Warnf("cache get %s: %v", key, err)
+		return false, nil
+	}
+	if !ok {
+		return false, nil
+	}
+	return s.decode(ctx, key, value, dst)
+}
+
+// decode will decode a cached value into dst, see load
+func (s store) decode(ctx context.Context, key string, value []byte, dst interface{}) (bool, error) {
+	if bytes.Equal(value, notFound) {
+		return true, domain.ErrNotFound
+	}
+	if err := json.Unmarshal(value, dst); err != nil {
+		logger.FromContext(ctx).Warnf("cache decode %s: %v", key, err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// save will cache the result of a storage lookup: the entity when found, and
+// that it does not exist, for a shorter time, when the lookup failed with domain.ErrNotFound
+func (s store) save(ctx context.Context, key string, value interface{}, errLookup error) {
+	var (
+		encoded []byte
+		ttl     = s.ttl
+		err     error
+	)
+	switch errLookup {
+	case nil:
+		if encoded, err = json.Marshal(value); err != nil {
+			logger.FromContext(ctx).Warnf("cache encode %s: %v", key, err)
+			return
+		}
+	case domain.ErrNotFound:
+		if s.negativeTTL <= 0 {
+			return
+		}
+		encoded, ttl = notFound, s.negativeTTL
+	default:
+		return
+	}
+
+	if err = s.cache.Set(ctx, key, encoded, ttl); err != nil {
+		logger.FromContext(ctx).Warnf("cache set %s: %v", key, err)
+	}
+}
+
+// invalidate will remove the values cached at keys after a write. A failure
+// leaves stale values in the cache until they expire, so it is logged as an error.
+func (s store) invalidate(ctx context.Context, keys ...string) {
+	if err := s.cache.Delete(ctx, keys...); err != nil {
+		logger.FromContext(ctx).Errorf("cache delete %v: %v", keys, err)
+	}
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+)
+
+type memoryAPIKeyRepository struct {
+	DB *DB
+}
+
+// NewAPIKeyRepository will create an object that represent the apikey.Repository interface
+func NewAPIKeyRepository(db *DB) repositories.APIKeyRepository {
+	return &memoryAPIKeyRepository{db}
+}
+
+func (m *memoryAPIKeyRepository) Fetch(ctx context.Context) ([]entities.APIKey, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	list := make([]entities.APIKey, 0, len(m.DB.apiKeys))
+	for _, k := range m.DB.apiKeys {
+		list = append(list, k)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (m *memoryAPIKeyRepository) GetByID(ctx context.Context, id int64) (entities.APIKey, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	k, ok := m.DB.apiKeys[id]
+	if !ok {
+		return entities.APIKey{}, domain.ErrNotFound
+	}
+	return k, nil
+}
+
+func (m *memoryAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (entities.APIKey, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	for _, k := range m.DB.apiKeys {
+		if k.Prefix == prefix {
+			return k, nil
+		}
+	}
+	return entities.APIKey{}, domain.ErrNotFound
+}
+
+func (m *memoryAPIKeyRepository) Store(ctx context.Context, k *entities.APIKey) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	m.DB.lastAPIKeyID++
+	k.ID = m.DB.lastAPIKeyID
+	m.DB.apiKeys[k.ID] = *k
+	return nil
+}
+
+func (m *memoryAPIKeyRepository) Revoke(ctx context.Context, id int64, revokedAt time.Time) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	k, ok := m.DB.apiKeys[id]
+	if !ok || k.RevokedAt != nil {
+		return domain.ErrNotFound
+	}
+	k.RevokedAt = &revokedAt
+	m.DB.apiKeys[id] = k
+	return nil
+}
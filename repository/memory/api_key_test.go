@@ -0,0 +1,40 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/memory"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	db := memory.NewDB()
+	k := memory.NewAPIKeyRepository(db)
+
+	reader := entities.APIKey{Name: "reader", Prefix: "0001", Hash: "h1", Scopes: []string{entities.ScopeArticlesRead}}
+	require.NoError(t, k.Store(context.TODO(), &reader))
+	writer := entities.APIKey{Name: "writer", Prefix: "0002", Hash: "h2", Scopes: []string{entities.ScopeArticlesWrite}, AuthorID: 1}
+	require.NoError(t, k.Store(context.TODO(), &writer))
+
+	list, err := k.Fetch(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []entities.APIKey{reader, writer}, list)
+
+	res, err := k.GetByPrefix(context.TODO(), "0002")
+	require.NoError(t, err)
+	assert.Equal(t, writer, res)
+	_, err = k.GetByPrefix(context.TODO(), "0003")
+	assert.Equal(t, domain.ErrNotFound, err)
+
+	require.NoError(t, k.Revoke(context.TODO(), reader.ID, time.Now()))
+	assert.Equal(t, domain.ErrNotFound, k.Revoke(context.TODO(), reader.ID, time.Now()))
+	res, err = k.GetByID(context.TODO(), reader.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, res.RevokedAt)
+}
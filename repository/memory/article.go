@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/search"
+)
+
+type memoryArticleRepository struct {
+	DB *DB
+}
+
+// NewArticleRepository will create an object that represent the article.Repository interface
+func NewArticleRepository(db *DB) repositories.ArticleRepository {
+	return &memoryArticleRepository{db}
+}
+
+// fetch will return the page of the articles accepted by the filter. The caller must hold the read lock.
+func (m *memoryArticleRepository) fetch(cursor string, num int64, filter func(entities.Article) bool) (res []entities.Article,
+	nextCursor string, prevCursor string, err error) {
+	list := make([]entities.Article, 0)
+	for _, ar := range m.DB.articles {
+		if filter(ar) {
+			list = append(list, ar)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].ID < list[j].ID
+		}
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	page, nextCursor, prevCursor, err := paginate(cursor, num, len(list), func(i int) (time.Time, int64) {
+		return list[i].CreatedAt, list[i].ID
+	})
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	res = make([]entities.Article, len(page))
+	for index, i := range page {
+		res[index] = list[i]
+	}
+	return res, nextCursor, prevCursor, nil
+}
+
+func (m *memoryArticleRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, string, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	return m.fetch(cursor, num, func(entities.Article) bool {
+		return true
+	})
+}
+
+func (m *memoryArticleRepository) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) ([]entities.Article, string,
+	string, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	return m.fetch(cursor, num, func(ar entities.Article) bool {
+		for categoryID := range m.DB.articleCategories[ar.ID] {
+			if m.DB.categories[categoryID].Tag == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (m *memoryArticleRepository) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) ([]entities.Article,
+	string, string, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	return m.fetch(cursor, num, func(ar entities.Article) bool {
+		return ar.Author.ID == authorID
+	})
+}
+
+func (m *memoryArticleRepository) GetByID(ctx context.Context, id int64) (entities.Article, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	ar, ok := m.DB.articles[id]
+	if !ok {
+		return entities.Article{}, domain.ErrNotFound
+	}
+	return ar, nil
+}
+
+func (m *memoryArticleRepository) GetByTitle(ctx context.Context, title string) (entities.Article, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	for _, ar := range m.DB.articles {
+		if ar.Title == title {
+			return ar, nil
+		}
+	}
+	return entities.Article{}, domain.ErrNotFound
+}
+
+func (m *memoryArticleRepository) Search(ctx context.Context, query string, cursor string, num int64) ([]entities.ArticleMatch,
+	string, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	terms := search.Terms(query)
+	matches := make([]entities.ArticleMatch, 0)
+	for _, ar := range m.DB.articles {
+		if score := search.Score(ar.Title, ar.Content, terms); score > 0 {
+			matches = append(matches, entities.ArticleMatch{Article: ar, Score: score})
+		}
+	}
+
+	return search.Page(matches, cursor, num)
+}
+
+func (m *memoryArticleRepository) Store(ctx context.Context, a *entities.Article) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	m.DB.lastArticleID++
+	a.ID = m.DB.lastArticleID
+	a.Version = 1
+
+	stored := *a
+	stored.Author = entities.Author{ID: a.Author.ID}
+	stored.Categories = nil
+	m.DB.articles[a.ID] = stored
+	return nil
+}
+
+func (m *memoryArticleRepository) Delete(ctx context.Context, id int64, version int64) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	ar, ok := m.DB.articles[id]
+	if !ok || ar.Version != version {
+		return domain.ErrPreconditionFailed
+	}
+
+	delete(m.DB.articles, id)
+	delete(m.DB.articleCategories, id)
+	return nil
+}
+
+func (m *memoryArticleRepository) Update(ctx context.Context, ar *entities.Article) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	stored, ok := m.DB.articles[ar.ID]
+	if !ok || stored.Version != ar.Version {
+		return domain.ErrPreconditionFailed
+	}
+
+	stored.Title = ar.Title
+	stored.Content = ar.Content
+	stored.Author = entities.Author{ID: ar.Author.ID}
+	stored.UpdatedAt = ar.UpdatedAt
+	stored.Version++
+	m.DB.articles[ar.ID] = stored
+
+	ar.Version = stored.Version
+	return nil
+}
@@ -0,0 +1,256 @@
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+	"github.com/tolbier/go-clean-arch/repository/memory"
+)
+
+func storeArticles(t *testing.T, db *memory.DB, n int) []entities.Article {
+	a := memory.NewArticleRepository(db)
+	base := time.Date(2017, 5, 18, 13, 50, 19, 0, time.UTC)
+
+	res := make([]entities.Article, 0, n)
+	for i := 0; i < n; i++ {
+		ar := entities.Article{
+			Title:     fmt.Sprintf("title %d", i),
+			Content:   "content",
+			Author:    entities.Author{ID: int64(i%2 + 1)},
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+			UpdatedAt: base,
+		}
+		require.NoError(t, a.Store(context.TODO(), &ar))
+		res = append(res, ar)
+	}
+	return res
+}
+
+func TestFetch(t *testing.T) {
+	db := memory.NewDB()
+	storeArticles(t, db, 5)
+	a := memory.NewArticleRepository(db)
+
+	list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), "", 2)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "title 0", list[0].Title)
+	assert.NotEmpty(t, nextCursor)
+	assert.Empty(t, prevCursor)
+
+	list, nextCursor, prevCursor, err = a.Fetch(context.TODO(), nextCursor, 2)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "title 2", list[0].Title)
+	assert.NotEmpty(t, prevCursor)
+
+	list, nextCursor, _, err = a.Fetch(context.TODO(), nextCursor, 2)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Empty(t, nextCursor)
+
+	// back to the first page
+	list, _, prevCursor, err = a.Fetch(context.TODO(), prevCursor, 2)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "title 0", list[0].Title)
+	assert.Equal(t, "title 1", list[1].Title)
+	assert.Empty(t, prevCursor)
+
+	_, _, _, err = a.Fetch(context.TODO(), "not a cursor", 2)
+	assert.Equal(t, domain.ErrBadParamInput, err)
+
+	list, nextCursor, prevCursor, err = a.Fetch(context.TODO(), "", -1)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	assert.Empty(t, nextCursor)
+	assert.Empty(t, prevCursor)
+}
+
+func TestFetchDesc(t *testing.T) {
+	db := memory.NewDB()
+	storeArticles(t, db, 3)
+	a := memory.NewArticleRepository(db)
+
+	list, nextCursor, _, err := a.Fetch(context.TODO(), repository.StartCursor(true), 2)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "title 2", list[0].Title)
+	assert.Equal(t, "title 1", list[1].Title)
+
+	list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), nextCursor, 2)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "title 0", list[0].Title)
+	assert.Empty(t, nextCursor)
+
+	list, _, _, err = a.Fetch(context.TODO(), prevCursor, 2)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "title 2", list[0].Title)
+}
+
+func TestFetchSameCreatedAt(t *testing.T) {
+	db := memory.NewDB()
+	a := memory.NewArticleRepository(db)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		ar := entities.Article{Title: fmt.Sprintf("title %d", i), CreatedAt: now, UpdatedAt: now}
+		require.NoError(t, a.Store(context.TODO(), &ar))
+	}
+
+	seen := make([]string, 0)
+	cursor := ""
+	for {
+		list, nextCursor, _, err := a.Fetch(context.TODO(), cursor, 1)
+		require.NoError(t, err)
+		for _, ar := range list {
+			seen = append(seen, ar.Title)
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	assert.Equal(t, []string{"title 0", "title 1", "title 2"}, seen)
+}
+
+func TestFetchByAuthor(t *testing.T) {
+	db := memory.NewDB()
+	storeArticles(t, db, 5)
+	a := memory.NewArticleRepository(db)
+
+	list, _, _, err := a.FetchByAuthor(context.TODO(), 2, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	for _, ar := range list {
+		assert.Equal(t, int64(2), ar.Author.ID)
+	}
+}
+
+func TestFetchByCategory(t *testing.T) {
+	db := memory.NewDB()
+	articles := storeArticles(t, db, 3)
+	a := memory.NewArticleRepository(db)
+	c := memory.NewCategoryRepository(db)
+
+	food := entities.Category{Name: "Makanan", Tag: "food"}
+	require.NoError(t, c.Store(context.TODO(), &food))
+	require.NoError(t, c.Attach(context.TODO(), articles[1].ID, food.ID))
+
+	list, _, _, err := a.FetchByCategory(context.TODO(), "food", "", 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, articles[1].ID, list[0].ID)
+}
+
+func TestGetByIDAndTitle(t *testing.T) {
+	db := memory.NewDB()
+	articles := storeArticles(t, db, 2)
+	a := memory.NewArticleRepository(db)
+
+	res, err := a.GetByID(context.TODO(), articles[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "title 1", res.Title)
+	assert.Equal(t, int64(1), res.Version)
+
+	res, err = a.GetByTitle(context.TODO(), "title 0")
+	require.NoError(t, err)
+	assert.Equal(t, articles[0].ID, res.ID)
+
+	_, err = a.GetByID(context.TODO(), 42)
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = a.GetByTitle(context.TODO(), "unknown")
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestSearch(t *testing.T) {
+	db := memory.NewDB()
+	a := memory.NewArticleRepository(db)
+	for _, ar := range []entities.Article{
+		{Title: "Makan Ayam", Content: "<p>ayam goreng</p>"},
+		{Title: "Makan Ikan", Content: "<p>ikan bakar</p>"},
+		{Title: "Makan Sayur", Content: "<p>sayur dan ayam</p>"},
+	} {
+		ar := ar
+		require.NoError(t, a.Store(context.TODO(), &ar))
+	}
+
+	list, nextCursor, err := a.Search(context.TODO(), "ayam", "", 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "Makan Ayam", list[0].Title)
+	assert.NotEmpty(t, nextCursor)
+
+	list, nextCursor, err = a.Search(context.TODO(), "ayam", nextCursor, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "Makan Sayur", list[0].Title)
+
+	list, _, err = a.Search(context.TODO(), "ayam", nextCursor, 1)
+	require.NoError(t, err)
+	assert.Len(t, list, 0)
+}
+
+func TestUpdate(t *testing.T) {
+	db := memory.NewDB()
+	articles := storeArticles(t, db, 1)
+	a := memory.NewArticleRepository(db)
+
+	ar := articles[0]
+	ar.Title = "Judul"
+	require.NoError(t, a.Update(context.TODO(), &ar))
+	assert.Equal(t, int64(2), ar.Version)
+
+	res, err := a.GetByID(context.TODO(), ar.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Judul", res.Title)
+
+	stale := articles[0]
+	err = a.Update(context.TODO(), &stale)
+	assert.Equal(t, domain.ErrPreconditionFailed, err)
+}
+
+func TestDelete(t *testing.T) {
+	db := memory.NewDB()
+	articles := storeArticles(t, db, 1)
+	a := memory.NewArticleRepository(db)
+
+	err := a.Delete(context.TODO(), articles[0].ID, 7)
+	assert.Equal(t, domain.ErrPreconditionFailed, err)
+
+	require.NoError(t, a.Delete(context.TODO(), articles[0].ID, 1))
+	_, err = a.GetByID(context.TODO(), articles[0].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestConcurrentStore(t *testing.T) {
+	db := memory.NewDB()
+	a := memory.NewArticleRepository(db)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ar := entities.Article{Title: fmt.Sprintf("title %d", i), CreatedAt: time.Now()}
+			assert.NoError(t, a.Store(context.TODO(), &ar))
+			_, _, _, err := a.Fetch(context.TODO(), "", 10)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	list, _, _, err := a.Fetch(context.TODO(), "", 100)
+	require.NoError(t, err)
+	assert.Len(t, list, 50)
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+)
+
+type memoryAuthorRepository struct {
+	DB *DB
+}
+
+// NewAuthorRepository will create an implementation of author.Repository
+func NewAuthorRepository(db *DB) repositories.AuthorRepository {
+	return &memoryAuthorRepository{db}
+}
+
+func (m *memoryAuthorRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Author, string, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	list := make([]entities.Author, 0, len(m.DB.authors))
+	for _, a := range m.DB.authors {
+		list = append(list, a)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].ID < list[j].ID
+		}
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	page, nextCursor, _, err := paginate(cursor, num, len(list), func(i int) (time.Time, int64) {
+		return list[i].CreatedAt, list[i].ID
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	res := make([]entities.Author, len(page))
+	for index, i := range page {
+		res[index] = list[i]
+	}
+	return res, nextCursor, nil
+}
+
+func (m *memoryAuthorRepository) GetByID(ctx context.Context, id int64) (entities.Author, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	a, ok := m.DB.authors[id]
+	if !ok {
+		return entities.Author{}, domain.ErrNotFound
+	}
+	return a, nil
+}
+
+func (m *memoryAuthorRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]entities.Author, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	res := make(map[int64]entities.Author)
+	for _, id := range ids {
+		if a, ok := m.DB.authors[id]; ok {
+			res[id] = a
+		}
+	}
+	return res, nil
+}
+
+func (m *memoryAuthorRepository) Store(ctx context.Context, a *entities.Author) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	m.DB.lastAuthorID++
+	a.ID = m.DB.lastAuthorID
+	m.DB.authors[a.ID] = *a
+	return nil
+}
+
+func (m *memoryAuthorRepository) Update(ctx context.Context, a *entities.Author) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	stored, ok := m.DB.authors[a.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	stored.Name = a.Name
+	stored.UpdatedAt = a.UpdatedAt
+	m.DB.authors[a.ID] = stored
+	return nil
+}
+
+func (m *memoryAuthorRepository) Delete(ctx context.Context, id int64, reassignTo int64) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	if _, ok := m.DB.authors[id]; !ok {
+		return domain.ErrNotFound
+	}
+	for articleID, ar := range m.DB.articles {
+		if ar.Author.ID != id {
+			continue
+		}
+		if reassignTo == 0 {
+			return domain.ErrAuthorHasArticles
+		}
+		ar.Author = entities.Author{ID: reassignTo}
+		ar.Version++
+		m.DB.articles[articleID] = ar
+	}
+	delete(m.DB.authors, id)
+	return nil
+}
@@ -0,0 +1,71 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/memory"
+)
+
+func TestAuthorRepository(t *testing.T) {
+	db := memory.NewDB()
+	a := memory.NewAuthorRepository(db)
+	now := time.Now()
+
+	first := entities.Author{Name: "Iman Tumorang", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, a.Store(context.TODO(), &first))
+	second := entities.Author{Name: "Bxcodec", CreatedAt: now.Add(time.Second), UpdatedAt: now}
+	require.NoError(t, a.Store(context.TODO(), &second))
+	assert.NotEqual(t, first.ID, second.ID)
+
+	list, nextCursor, err := a.Fetch(context.TODO(), "", 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, first.ID, list[0].ID)
+
+	list, _, err = a.Fetch(context.TODO(), nextCursor, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, second.ID, list[0].ID)
+
+	byID, err := a.GetByIDs(context.TODO(), []int64{first.ID, second.ID, 42})
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+	assert.Equal(t, "Iman Tumorang", byID[first.ID].Name)
+
+	second.Name = "Iman"
+	require.NoError(t, a.Update(context.TODO(), &second))
+	res, err := a.GetByID(context.TODO(), second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Iman", res.Name)
+
+	require.NoError(t, a.Delete(context.TODO(), second.ID, 0))
+	_, err = a.GetByID(context.TODO(), second.ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.Equal(t, domain.ErrNotFound, a.Delete(context.TODO(), second.ID, 0))
+}
+
+func TestAuthorDeleteReassigning(t *testing.T) {
+	db := memory.NewDB()
+	a := memory.NewAuthorRepository(db)
+	for _, name := range []string{"Iman Tumorang", "Bxcodec"} {
+		require.NoError(t, a.Store(context.TODO(), &entities.Author{Name: name}))
+	}
+	storeArticles(t, db, 4)
+	ar := memory.NewArticleRepository(db)
+
+	assert.Equal(t, domain.ErrAuthorHasArticles, a.Delete(context.TODO(), 1, 0))
+	require.NoError(t, a.Delete(context.TODO(), 1, 2))
+
+	_, err := a.GetByID(context.TODO(), 1)
+	assert.Equal(t, domain.ErrNotFound, err)
+	list, _, _, err := ar.FetchByAuthor(context.TODO(), 2, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 4)
+}
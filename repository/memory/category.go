@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+)
+
+type memoryCategoryRepository struct {
+	DB *DB
+}
+
+// NewCategoryRepository will create an object that represent the category.Repository interface
+func NewCategoryRepository(db *DB) repositories.CategoryRepository {
+	return &memoryCategoryRepository{db}
+}
+
+func sortCategories(list []entities.Category) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+}
+
+func (m *memoryCategoryRepository) Fetch(ctx context.Context) ([]entities.Category, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	list := make([]entities.Category, 0, len(m.DB.categories))
+	for _, c := range m.DB.categories {
+		list = append(list, c)
+	}
+	sortCategories(list)
+	return list, nil
+}
+
+func (m *memoryCategoryRepository) GetByID(ctx context.Context, id int64) (entities.Category, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	c, ok := m.DB.categories[id]
+	if !ok {
+		return entities.Category{}, domain.ErrNotFound
+	}
+	return c, nil
+}
+
+func (m *memoryCategoryRepository) GetByTag(ctx context.Context, tag string) (entities.Category, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	for _, c := range m.DB.categories {
+		if c.Tag == tag {
+			return c, nil
+		}
+	}
+	return entities.Category{}, domain.ErrNotFound
+}
+
+func (m *memoryCategoryRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]entities.Category, error) {
+	m.DB.mu.RLock()
+	defer m.DB.mu.RUnlock()
+
+	res := make(map[int64][]entities.Category)
+	for _, articleID := range articleIDs {
+		for categoryID := range m.DB.articleCategories[articleID] {
+			res[articleID] = append(res[articleID], m.DB.categories[categoryID])
+		}
+		sortCategories(res[articleID])
+	}
+	return res, nil
+}
+
+func (m *memoryCategoryRepository) Store(ctx context.Context, c *entities.Category) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	m.DB.lastCategoryID++
+	c.ID = m.DB.lastCategoryID
+	m.DB.categories[c.ID] = *c
+	return nil
+}
+
+func (m *memoryCategoryRepository) Update(ctx context.Context, c *entities.Category) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	stored, ok := m.DB.categories[c.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	stored.Name = c.Name
+	stored.Tag = c.Tag
+	stored.UpdatedAt = c.UpdatedAt
+	m.DB.categories[c.ID] = stored
+	return nil
+}
+
+func (m *memoryCategoryRepository) Delete(ctx context.Context, id int64) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	if _, ok := m.DB.categories[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(m.DB.categories, id)
+	for _, categories := range m.DB.articleCategories {
+		delete(categories, id)
+	}
+	return nil
+}
+
+func (m *memoryCategoryRepository) Attach(ctx context.Context, articleID int64, categoryID int64) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	if m.DB.articleCategories[articleID] == nil {
+		m.DB.articleCategories[articleID] = make(map[int64]bool)
+	}
+	m.DB.articleCategories[articleID][categoryID] = true
+	return nil
+}
+
+func (m *memoryCategoryRepository) Detach(ctx context.Context, articleID int64, categoryID int64) error {
+	m.DB.mu.Lock()
+	defer m.DB.mu.Unlock()
+
+	if !m.DB.articleCategories[articleID][categoryID] {
+		return domain.ErrNotFound
+	}
+	delete(m.DB.articleCategories[articleID], categoryID)
+	return nil
+}
@@ -0,0 +1,48 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/memory"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	db := memory.NewDB()
+	c := memory.NewCategoryRepository(db)
+
+	food := entities.Category{Name: "Makanan", Tag: "food"}
+	require.NoError(t, c.Store(context.TODO(), &food))
+	life := entities.Category{Name: "Kehidupan", Tag: "life"}
+	require.NoError(t, c.Store(context.TODO(), &life))
+
+	list, err := c.Fetch(context.TODO())
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	res, err := c.GetByTag(context.TODO(), "life")
+	require.NoError(t, err)
+	assert.Equal(t, life.ID, res.ID)
+
+	require.NoError(t, c.Attach(context.TODO(), 1, life.ID))
+	require.NoError(t, c.Attach(context.TODO(), 1, food.ID))
+	require.NoError(t, c.Attach(context.TODO(), 1, food.ID))
+
+	byArticle, err := c.GetByArticleIDs(context.TODO(), []int64{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Category{food, life}, byArticle[1])
+	assert.Empty(t, byArticle[2])
+
+	require.NoError(t, c.Detach(context.TODO(), 1, food.ID))
+	assert.Equal(t, domain.ErrNotFound, c.Detach(context.TODO(), 1, food.ID))
+
+	require.NoError(t, c.Delete(context.TODO(), life.ID))
+	byArticle, err = c.GetByArticleIDs(context.TODO(), []int64{1})
+	require.NoError(t, err)
+	assert.Empty(t, byArticle[1])
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+)
+
+// DB is the in-memory storage shared by the repositories of this package,
+// it plays the role the *sql.DB connection plays for the mysql repositories
+type DB struct {
+	mu sync.RWMutex
+
+	articles          map[int64]entities.Article
+	authors           map[int64]entities.Author
+	categories        map[int64]entities.Category
+	articleCategories map[int64]map[int64]bool
+	apiKeys           map[int64]entities.APIKey
+
+	lastArticleID  int64
+	lastAuthorID   int64
+	lastCategoryID int64
+	lastAPIKeyID   int64
+}
+
+// NewDB will create an empty in-memory storage
+func NewDB() *DB {
+	return &DB{
+		articles:          make(map[int64]entities.Article),
+		authors:           make(map[int64]entities.Author),
+		categories:        make(map[int64]entities.Category),
+		articleCategories: make(map[int64]map[int64]bool),
+		apiKeys:           make(map[int64]entities.APIKey),
+	}
+}
+
+// paginate will apply the keyset pagination used by the sql repositories to
+// a list of total items sorted by creation time then id: it returns the
+// indexes of the items of the page, in listing order, and the cursors of the
+// pages around it
+func paginate(cursor string, num int64, total int, position func(i int) (time.Time, int64)) (page []int, nextCursor string,
+	prevCursor string, err error) {
+	decodedCursor, err := repository.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", "", domain.ErrBadParamInput
+	}
+
+	op, _ := decodedCursor.Keyset()
+	createdAt, id := decodedCursor.Position()
+	past := func(i int) bool {
+		t, itemID := position(i)
+		if op == "<" {
+			return t.Before(createdAt) || t.Equal(createdAt) && itemID < id
+		}
+		return t.After(createdAt) || t.Equal(createdAt) && itemID > id
+	}
+
+	page = make([]int, 0)
+	for k := 0; k < total && int64(len(page)) <= num; k++ {
+		i := k
+		if op == "<" {
+			i = total - 1 - k
+		}
+		if past(i) {
+			page = append(page, i)
+		}
+	}
+
+	n, nextCursor, prevCursor := repository.Paginate(decodedCursor, len(page), num, func(i, j int) {
+		page[i], page[j] = page[j], page[i]
+	}, func(i int) (time.Time, int64) {
+		return position(page[i])
+	})
+	return page[:n], nextCursor, prevCursor, nil
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libmetrics "github.com/tolbier/go-clean-arch/lib/metrics"
+)
+
+// instrumentedArticleRepository records the latency and the errors of the calls to an article.Repository
+type instrumentedArticleRepository struct {
+	next    repositories.ArticleRepository
+	metrics *libmetrics.Metrics
+}
+
+// NewInstrumentedArticleRepository will create an implementation of article.Repository,
+// recording the calls to next with m
+func NewInstrumentedArticleRepository(next repositories.ArticleRepository, m *libmetrics.Metrics) repositories.ArticleRepository {
+	return &instrumentedArticleRepository{next: next, metrics: m}
+}
+
+func (r *instrumentedArticleRepository) observe(method string, start time.Time, err *error) {
+	r.metrics.ObserveCall(libmetrics.LayerRepository, "article", method, start, *err)
+}
+
+func (r *instrumentedArticleRepository) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Article,
+	nextCursor string, prevCursor string, err error) {
+	defer r.observe("Fetch", time.Now(), &err)
+	return r.next.Fetch(ctx, cursor, num)
+}
+
+func (r *instrumentedArticleRepository) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	defer r.observe("FetchByCategory", time.Now(), &err)
+	return r.next.FetchByCategory(ctx, tag, cursor, num)
+}
+
+func (r *instrumentedArticleRepository) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	defer r.observe("FetchByAuthor", time.Now(), &err)
+	return r.next.FetchByAuthor(ctx, authorID, cursor, num)
+}
+
+func (r *instrumentedArticleRepository) GetByID(ctx context.Context, id int64) (res entities.Article, err error) {
+	defer r.observe("GetByID", time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *instrumentedArticleRepository) GetByTitle(ctx context.Context, title string) (res entities.Article, err error) {
+	defer r.observe("GetByTitle", time.Now(), &err)
+	return r.next.GetByTitle(ctx, title)
+}
+
+func (r *instrumentedArticleRepository) Search(ctx context.Context, query string, cursor string, num int64) (
+	res []entities.ArticleMatch, nextCursor string, err error) {
+	defer r.observe("Search", time.Now(), &err)
+	return r.next.Search(ctx, query, cursor, num)
+}
+
+func (r *instrumentedArticleRepository) Update(ctx context.Context, ar *entities.Article) (err error) {
+	defer r.observe("Update", time.Now(), &err)
+	return r.next.Update(ctx, ar)
+}
+
+func (r *instrumentedArticleRepository) Store(ctx context.Context, a *entities.Article) (err error) {
+	defer r.observe("Store", time.Now(), &err)
+	return r.next.Store(ctx, a)
+}
+
+func (r *instrumentedArticleRepository) Delete(ctx context.Context, id int64, version int64) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id, version)
+}
@@ -0,0 +1,45 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	libmetrics "github.com/tolbier/go-clean-arch/lib/metrics"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+	"github.com/tolbier/go-clean-arch/repository/metrics"
+)
+
+func TestInstrumentedArticleRepository(t *testing.T) {
+	m, err := libmetrics.New(prometheus.NewRegistry())
+	require.NoError(t, err)
+	mockArticleRepo := new(ArticleRepository)
+	r := metrics.NewInstrumentedArticleRepository(mockArticleRepo, m)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(1)).Return(entities.Article{ID: 1}, nil).Once()
+
+		res, err := r.GetByID(context.TODO(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.ID)
+		assert.Equal(t, float64(0), testutil.ToFloat64(m.CallErrors.WithLabelValues(libmetrics.LayerRepository, "article", "GetByID", "not_found")))
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, int64(2)).Return(entities.Article{}, domain.ErrNotFound).Once()
+
+		_, err := r.GetByID(context.TODO(), 2)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.CallErrors.WithLabelValues(libmetrics.LayerRepository, "article", "GetByID", "not_found")))
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libmetrics "github.com/tolbier/go-clean-arch/lib/metrics"
+)
+
+// instrumentedAuthorRepository records the latency and the errors of the calls to an author.Repository
+type instrumentedAuthorRepository struct {
+	next    repositories.AuthorRepository
+	metrics *libmetrics.Metrics
+}
+
+// NewInstrumentedAuthorRepository will create an implementation of author.Repository,
+// recording the calls to next with m
+func NewInstrumentedAuthorRepository(next repositories.AuthorRepository, m *libmetrics.Metrics) repositories.AuthorRepository {
+	return &instrumentedAuthorRepository{next: next, metrics: m}
+}
+
+func (r *instrumentedAuthorRepository) observe(method string, start time.Time, err *error) {
+	r.metrics.ObserveCall(libmetrics.LayerRepository, "author", method, start, *err)
+}
+
+func (r *instrumentedAuthorRepository) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Author,
+	nextCursor string, err error) {
+	defer r.observe("Fetch", time.Now(), &err)
+	return r.next.Fetch(ctx, cursor, num)
+}
+
+func (r *instrumentedAuthorRepository) GetByID(ctx context.Context, id int64) (res entities.Author, err error) {
+	defer r.observe("GetByID", time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *instrumentedAuthorRepository) GetByIDs(ctx context.Context, ids []int64) (res map[int64]entities.Author, err error) {
+	defer r.observe("GetByIDs", time.Now(), &err)
+	return r.next.GetByIDs(ctx, ids)
+}
+
+func (r *instrumentedAuthorRepository) Update(ctx context.Context, a *entities.Author) (err error) {
+	defer r.observe("Update", time.Now(), &err)
+	return r.next.Update(ctx, a)
+}
+
+func (r *instrumentedAuthorRepository) Store(ctx context.Context, a *entities.Author) (err error) {
+	defer r.observe("Store", time.Now(), &err)
+	return r.next.Store(ctx, a)
+}
+
+func (r *instrumentedAuthorRepository) Delete(ctx context.Context, id int64, reassignTo int64) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id, reassignTo)
+}
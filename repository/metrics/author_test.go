@@ -0,0 +1,32 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	libmetrics "github.com/tolbier/go-clean-arch/lib/metrics"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+	"github.com/tolbier/go-clean-arch/repository/metrics"
+)
+
+func TestInstrumentedAuthorRepository(t *testing.T) {
+	m, err := libmetrics.New(prometheus.NewRegistry())
+	require.NoError(t, err)
+	mockAuthorRepo := new(AuthorRepository)
+	r := metrics.NewInstrumentedAuthorRepository(mockAuthorRepo, m)
+
+	mockAuthorRepo.On("Delete", mock.Anything, int64(1), int64(0)).Return(errors.New("connection refused")).Once()
+
+	err = r.Delete(context.TODO(), 1, 0)
+
+	assert.Error(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.CallErrors.WithLabelValues(libmetrics.LayerRepository, "author", "Delete", "internal")))
+	mockAuthorRepo.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package apikey_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/mysql/apikey"
+)
+
+var columns = []string{"id", "name", "prefix", "hash", "scopes", "author_id", "created_at", "revoked_at"}
+
+func TestGetByPrefix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key WHERE prefix = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, "importer", "0001", "h1", "articles:read articles:write", 2, time.Now(), nil)
+		mock.ExpectQuery(query).WithArgs("0001").WillReturnRows(rows)
+		k := apikey.NewMysqlAPIKeyRepository(db)
+
+		res, err := k.GetByPrefix(context.TODO(), "0001")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{entities.ScopeArticlesRead, entities.ScopeArticlesWrite}, res.Scopes)
+		assert.Nil(t, res.RevokedAt)
+	})
+	t.Run("revoked", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, "importer", "0001", "h1", "articles:read", 2, time.Now(), time.Now())
+		mock.ExpectQuery(query).WithArgs("0001").WillReturnRows(rows)
+		k := apikey.NewMysqlAPIKeyRepository(db)
+
+		res, err := k.GetByPrefix(context.TODO(), "0001")
+		assert.NoError(t, err)
+		assert.NotNil(t, res.RevokedAt)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs("0002").WillReturnRows(sqlmock.NewRows(columns))
+		k := apikey.NewMysqlAPIKeyRepository(db)
+
+		_, err := k.GetByPrefix(context.TODO(), "0002")
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	key := &entities.APIKey{
+		Name:      "importer",
+		Prefix:    "0001",
+		Hash:      "h1",
+		Scopes:    []string{entities.ScopeArticlesRead, entities.ScopeArticlesWrite},
+		AuthorID:  2,
+		CreatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT  api_key SET name=\\? , prefix=\\? , hash=\\? , scopes=\\? , author_id=\\? , created_at=\\?"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(key.Name, key.Prefix, key.Hash, "articles:read articles:write", key.AuthorID, key.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	k := apikey.NewMysqlAPIKeyRepository(db)
+
+	err = k.Store(context.TODO(), key)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), key.ID)
+}
+
+func TestRevoke(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	query := "UPDATE api_key SET revoked_at = \\? WHERE id = \\? AND revoked_at IS NULL"
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+		k := apikey.NewMysqlAPIKeyRepository(db)
+
+		assert.NoError(t, k.Revoke(context.TODO(), 7, now))
+	})
+	t.Run("already-revoked", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+		k := apikey.NewMysqlAPIKeyRepository(db)
+
+		assert.Equal(t, domain.ErrNotFound, k.Revoke(context.TODO(), 7, now))
+	})
+}
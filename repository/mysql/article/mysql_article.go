@@ -4,12 +4,12 @@ import (
     "context"
 	"database/sql"
 	"fmt"
+	"time"
 	"github.com/tolbier/go-clean-arch/domain"
 	"github.com/tolbier/go-clean-arch/domain/entities"
 	"github.com/tolbier/go-clean-arch/domain/repositories"
 
-	"github.com/sirupsen/logrus"
-
+    "github.com/tolbier/go-clean-arch/lib/logger"
     "github.com/tolbier/go-clean-arch/lib/repository"
 )
 
@@ -25,14 +25,14 @@ func NewMysqlArticleRepository(Conn *sql.DB) repositories.ArticleRepository {
 func (m *mysqlArticleRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.Article, err error) {
 	rows, err := m.Conn.QueryContext(ctx, query, args...)
 	if err != nil {
-		logrus.Error(err)
+		logger.FromContext(ctx).Error(err)
 		return nil, err
 	}
 
 	defer func() {
 		errRow := rows.Close()
 		if errRow != nil {
-			logrus.Error(errRow)
+			logger.FromContext(ctx).Error(errRow)
 		}
 	}()
 
@@ -47,10 +47,11 @@ func (m *mysqlArticleRepository) fetch(ctx context.Context, query string, args .
 			&authorID,
 			&t.UpdatedAt,
 			&t.CreatedAt,
+			&t.Version,
 		)
 
 		if err != nil {
-			logrus.Error(err)
+			logger.FromContext(ctx).Error(err)
 			return nil, err
 		}
 		t.Author = entities.Author{
@@ -62,28 +63,63 @@ func (m *mysqlArticleRepository) fetch(ctx context.Context, query string, args .
 	return result, nil
 }
 
-func (m *mysqlArticleRepository) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Article, nextCursor string, err error) {
-	query := `SELECT id,title,content, author_id, updated_at, created_at
-  						FROM article WHERE created_at > ? ORDER BY created_at LIMIT ? `
-
+// fetchPage will run query on the page of the listing described by cursor. The query
+// formats the keyset comparison as %[1]s and the sort order as %[2]s, and takes the
+// cursor position and the limit as its last arguments.
+func (m *mysqlArticleRepository) fetchPage(ctx context.Context, query string, cursor string, num int64,
+	args ...interface{}) (res []entities.Article, nextCursor string, prevCursor string, err error) {
 	decodedCursor, err := repository.DecodeCursor(cursor)
-	if err != nil && cursor != "" {
-		return nil, "", domain.ErrBadParamInput
+	if err != nil {
+		return nil, "", "", domain.ErrBadParamInput
 	}
 
-	res, err = m.fetch(ctx, query, decodedCursor, num)
+	op, order := decodedCursor.Keyset()
+	createdAt, id := decodedCursor.Position()
+	args = append(args, createdAt, createdAt, id, num+1)
+	res, err = m.fetch(ctx, fmt.Sprintf(query, op, order), args...)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
 	}
 
-	if len(res) == int(num) {
-		nextCursor = repository.EncodeCursor(res[len(res)-1].CreatedAt)
-	}
+	n, nextCursor, prevCursor := repository.Paginate(decodedCursor, len(res), num, func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	}, func(i int) (time.Time, int64) {
+		return res[i].CreatedAt, res[i].ID
+	})
+	return res[:n], nextCursor, prevCursor, nil
+}
 
-	return
+func (m *mysqlArticleRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, string, error) {
+	query := `SELECT id,title,content, author_id, updated_at, created_at, version
+  						FROM article WHERE (created_at %[1]s ? OR (created_at = ? AND id %[1]s ?))
+  						ORDER BY created_at %[2]s, id %[2]s LIMIT ? `
+
+	return m.fetchPage(ctx, query, cursor, num)
 }
+
+func (m *mysqlArticleRepository) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) ([]entities.Article, string,
+	string, error) {
+	query := `SELECT a.id, a.title, a.content, a.author_id, a.updated_at, a.created_at, a.version
+  						FROM article a
+  						JOIN article_category ac ON ac.article_id = a.id
+  						JOIN category c ON c.id = ac.category_id
+  						WHERE c.tag = ? AND (a.created_at %[1]s ? OR (a.created_at = ? AND a.id %[1]s ?))
+  						ORDER BY a.created_at %[2]s, a.id %[2]s LIMIT ? `
+
+	return m.fetchPage(ctx, query, cursor, num, tag)
+}
+
+func (m *mysqlArticleRepository) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) ([]entities.Article,
+	string, string, error) {
+	query := `SELECT id,title,content, author_id, updated_at, created_at, version
+  						FROM article WHERE author_id = ? AND (created_at %[1]s ? OR (created_at = ? AND id %[1]s ?))
+  						ORDER BY created_at %[2]s, id %[2]s LIMIT ? `
+
+	return m.fetchPage(ctx, query, cursor, num, authorID)
+}
+
 func (m *mysqlArticleRepository) GetByID(ctx context.Context, id int64) (res entities.Article, err error) {
-	query := `SELECT id,title,content, author_id, updated_at, created_at
+	query := `SELECT id,title,content, author_id, updated_at, created_at, version
   						FROM article WHERE ID = ?`
 
 	list, err := m.fetch(ctx, query, id)
@@ -101,7 +137,7 @@ func (m *mysqlArticleRepository) GetByID(ctx context.Context, id int64) (res ent
 }
 
 func (m *mysqlArticleRepository) GetByTitle(ctx context.Context, title string) (res entities.Article, err error) {
-	query := `SELECT id,title,content, author_id, updated_at, created_at
+	query := `SELECT id,title,content, author_id, updated_at, created_at, version
   						FROM article WHERE title = ?`
 
 	list, err := m.fetch(ctx, query, title)
@@ -117,8 +153,66 @@ func (m *mysqlArticleRepository) GetByTitle(ctx context.Context, title string) (
 	return
 }
 
+func (m *mysqlArticleRepository) Search(ctx context.Context, query string, cursor string, num int64) (res []entities.ArticleMatch,
+	nextCursor string, err error) {
+	// the FULLTEXT index on (title, content) is created by the migrations
+	sqlQuery := `SELECT id,title,content, author_id, updated_at, created_at, version,
+  						MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+  						FROM article WHERE MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE)
+  						ORDER BY score DESC, id DESC LIMIT ? OFFSET ?`
+
+	offset, err := repository.DecodeOffsetCursor(cursor)
+	if err != nil {
+		return nil, "", domain.ErrBadParamInput
+	}
+
+	rows, err := m.Conn.QueryContext(ctx, sqlQuery, query, query, num, offset)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, "", err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	res = make([]entities.ArticleMatch, 0)
+	for rows.Next() {
+		t := entities.ArticleMatch{}
+		authorID := int64(0)
+		err = rows.Scan(
+			&t.ID,
+			&t.Title,
+			&t.Content,
+			&authorID,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.Version,
+			&t.Score,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, "", err
+		}
+		t.Author = entities.Author{
+			ID: authorID,
+		}
+		res = append(res, t)
+	}
+
+	if len(res) == int(num) {
+		nextCursor = repository.EncodeOffsetCursor(offset + num)
+	}
+
+	return
+}
+
 func (m *mysqlArticleRepository) Store(ctx context.Context, a *entities.Article) (err error) {
-	query := `INSERT  article SET title=? , content=? , author_id=?, updated_at=? , created_at=?`
+	query := `INSERT  article SET title=? , content=? , author_id=?, updated_at=? , created_at=? , version=1`
 	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
@@ -133,18 +227,33 @@ func (m *mysqlArticleRepository) Store(ctx context.Context, a *entities.Article)
 		return
 	}
 	a.ID = lastID
+	a.Version = 1
 	return
 }
 
-func (m *mysqlArticleRepository) Delete(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM article WHERE id = ?"
+func (m *mysqlArticleRepository) Delete(ctx context.Context, id int64, version int64) (err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logger.FromContext(ctx).Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	query := "DELETE FROM article WHERE id = ? AND version = ?"
+
+	stmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, id, version)
 	if err != nil {
 		return
 	}
@@ -154,22 +263,28 @@ func (m *mysqlArticleRepository) Delete(ctx context.Context, id int64) (err erro
 		return
 	}
 
+	if rowsAfected == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
 	if rowsAfected != 1 {
 		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", rowsAfected)
 		return
 	}
 
+	_, err = tx.ExecContext(ctx, "DELETE FROM article_category WHERE article_id = ?", id)
 	return
 }
+
 func (m *mysqlArticleRepository) Update(ctx context.Context, ar *entities.Article) (err error) {
-	query := `UPDATE article set title=?, content=?, author_id=?, updated_at=? WHERE ID = ?`
+	query := `UPDATE article set title=?, content=?, author_id=?, updated_at=?, version=version+1 WHERE ID = ? AND version = ?`
 
 	stmt, err := m.Conn.PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID)
+	res, err := stmt.ExecContext(ctx, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID, ar.Version)
 	if err != nil {
 		return
 	}
@@ -177,10 +292,15 @@ func (m *mysqlArticleRepository) Update(ctx context.Context, ar *entities.Articl
 	if err != nil {
 		return
 	}
+	if affect == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
 	if affect != 1 {
 		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
 		return
 	}
 
+	ar.Version++
 	return
 }
@@ -2,6 +2,7 @@ package article_test
 
 import (
     "context"
+    "github.com/tolbier/go-clean-arch/domain"
     "github.com/tolbier/go-clean-arch/domain/entities"
     "github.com/tolbier/go-clean-arch/repository/mysql/article"
     "testing"
@@ -30,22 +31,78 @@ func TestFetch(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at"}).
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "version"}).
 		AddRow(mockArticles[0].ID, mockArticles[0].Title, mockArticles[0].Content,
-			mockArticles[0].Author.ID, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt).
+			mockArticles[0].Author.ID, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt, 1).
 		AddRow(mockArticles[1].ID, mockArticles[1].Title, mockArticles[1].Content,
-			mockArticles[1].Author.ID, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt)
+			mockArticles[1].Author.ID, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt, 1)
 
-	query := "SELECT id,title,content, author_id, updated_at, created_at FROM article WHERE created_at > \\? ORDER BY created_at LIMIT \\?"
+	query := "SELECT id,title,content, author_id, updated_at, created_at, version FROM article " +
+		"WHERE \\(created_at > \\? OR \\(created_at = \\? AND id > \\?\\)\\) ORDER BY created_at ASC, id ASC LIMIT \\?"
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(0), int64(3)).WillReturnRows(rows)
 	a := article.NewMysqlArticleRepository(db)
-	cursor := repository.EncodeCursor(mockArticles[1].CreatedAt)
+	cursor := repository.EncodeCursor(repository.Cursor{CreatedAt: mockArticles[0].CreatedAt})
 	num := int64(2)
-	list, nextCursor, err := a.Fetch(context.TODO(), cursor, num)
-	assert.NotEmpty(t, nextCursor)
+	list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, num)
+	assert.Empty(t, nextCursor)
+	assert.NotEmpty(t, prevCursor)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func TestFetchPrev(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	// the articles before id 4 created at the same time, read backward
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "version"}).
+		AddRow(3, "title 3", "content 3", 1, now, now, 1).
+		AddRow(2, "title 2", "content 2", 1, now, now, 1).
+		AddRow(1, "title 1", "content 1", 1, now, now, 1)
+
+	query := "SELECT id,title,content, author_id, updated_at, created_at, version FROM article " +
+		"WHERE \\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(4), int64(3)).WillReturnRows(rows)
+	a := article.NewMysqlArticleRepository(db)
+	cursor := repository.EncodeCursor(repository.Cursor{CreatedAt: now, ID: 4, Prev: true})
+	list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, 2)
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
+	assert.Equal(t, int64(2), list[0].ID)
+	assert.Equal(t, int64(3), list[1].ID)
+	assert.NotEmpty(t, nextCursor)
+	assert.NotEmpty(t, prevCursor)
+
+	_, _, _, err = a.Fetch(context.TODO(), cursor+"x", 2)
+	assert.Equal(t, domain.ErrBadParamInput, err)
+}
+
+func TestFetchByCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), 1)
+
+	query := "SELECT a.id, a.title, a.content, a.author_id, a.updated_at, a.created_at, a.version FROM article a " +
+		"JOIN article_category ac ON ac.article_id = a.id JOIN category c ON c.id = ac.category_id " +
+		"WHERE c.tag = \\? AND \\(a.created_at > \\? OR \\(a.created_at = \\? AND a.id > \\?\\)\\) " +
+		"ORDER BY a.created_at ASC, a.id ASC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs("food", sqlmock.AnyArg(), sqlmock.AnyArg(), int64(0), int64(3)).WillReturnRows(rows)
+	a := article.NewMysqlArticleRepository(db)
+
+	list, nextCursor, _, err := a.FetchByCategory(context.TODO(), "food", "", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, nextCursor)
+	assert.Len(t, list, 1)
 }
 
 func TestGetByID(t *testing.T) {
@@ -54,10 +111,10 @@ func TestGetByID(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at"}).
-		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), 1)
 
-	query := "SELECT id,title,content, author_id, updated_at, created_at FROM article WHERE ID = \\?"
+	query := "SELECT id,title,content, author_id, updated_at, created_at, version FROM article WHERE ID = \\?"
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	a := article.NewMysqlArticleRepository(db)
@@ -85,7 +142,7 @@ func TestStore(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "INSERT  article SET title=\\? , content=\\? , author_id=\\?, updated_at=\\? , created_at=\\?"
+	query := "INSERT  article SET title=\\? , content=\\? , author_id=\\?, updated_at=\\? , created_at=\\? , version=1"
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.CreatedAt, ar.UpdatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
 
@@ -94,6 +151,7 @@ func TestStore(t *testing.T) {
 	err = a.Store(context.TODO(), ar)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(12), ar.ID)
+	assert.Equal(t, int64(1), ar.Version)
 }
 
 func TestGetByTitle(t *testing.T) {
@@ -102,10 +160,10 @@ func TestGetByTitle(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at"}).
-		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), 1)
 
-	query := "SELECT id,title,content, author_id, updated_at, created_at FROM article WHERE title = \\?"
+	query := "SELECT id,title,content, author_id, updated_at, created_at, version FROM article WHERE title = \\?"
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	a := article.NewMysqlArticleRepository(db)
@@ -122,16 +180,33 @@ func TestDelete(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "DELETE FROM article WHERE id = \\?"
-
-	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
-
-	a := article.NewMysqlArticleRepository(db)
-
-	num := int64(12)
-	err = a.Delete(context.TODO(), num)
-	assert.NoError(t, err)
+	query := "DELETE FROM article WHERE id = \\? AND version = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(12, 2).WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectExec("DELETE FROM article_category WHERE article_id = \\?").WithArgs(12).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		a := article.NewMysqlArticleRepository(db)
+
+		err = a.Delete(context.TODO(), 12, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("stale-version", func(t *testing.T) {
+		mock.ExpectBegin()
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(12, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		a := article.NewMysqlArticleRepository(db)
+
+		err = a.Delete(context.TODO(), 12, 1)
+		assert.Equal(t, domain.ErrPreconditionFailed, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -146,6 +221,7 @@ func TestUpdate(t *testing.T) {
 			ID:   1,
 			Name: "Iman Tumorang",
 		},
+		Version: 3,
 	}
 
 	db, mock, err := sqlmock.New()
@@ -153,13 +229,83 @@ func TestUpdate(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "UPDATE article set title=\\?, content=\\?, author_id=\\?, updated_at=\\? WHERE ID = \\?"
+	query := "UPDATE article set title=\\?, content=\\?, author_id=\\?, updated_at=\\?, version=version\\+1 WHERE ID = \\? AND version = \\?"
 
-	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	t.Run("success", func(t *testing.T) {
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID, ar.Version).WillReturnResult(sqlmock.NewResult(12, 1))
+
+		a := article.NewMysqlArticleRepository(db)
+
+		err = a.Update(context.TODO(), ar)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), ar.Version)
+	})
+	t.Run("stale-version", func(t *testing.T) {
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID, ar.Version).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		a := article.NewMysqlArticleRepository(db)
+
+		err = a.Update(context.TODO(), ar)
+		assert.Equal(t, domain.ErrPreconditionFailed, err)
+	})
+}
+
+func TestFetchByAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
 
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "version"}).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), 1).
+		AddRow(2, "title 2", "Content 2", 1, time.Now(), time.Now(), 1)
+
+	query := "SELECT id,title,content, author_id, updated_at, created_at, version FROM article " +
+		"WHERE author_id = \\? AND \\(created_at > \\? OR \\(created_at = \\? AND id > \\?\\)\\) " +
+		"ORDER BY created_at ASC, id ASC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs(int64(1), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(0), int64(2)).WillReturnRows(rows)
 	a := article.NewMysqlArticleRepository(db)
 
-	err = a.Update(context.TODO(), ar)
+	list, nextCursor, _, err := a.FetchByAuthor(context.TODO(), 1, "", 1)
 	assert.NoError(t, err)
+	assert.NotEmpty(t, nextCursor)
+	assert.Len(t, list, 1)
+}
+
+func TestSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id,title,content, author_id, updated_at, created_at, version, " +
+		"MATCH\\(title, content\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\) AS score " +
+		"FROM article WHERE MATCH\\(title, content\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\) " +
+		"ORDER BY score DESC, id DESC LIMIT \\? OFFSET \\?"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "updated_at", "created_at", "version", "score"}).
+			AddRow(2, "Makan Ayam", "Content 1", 1, time.Now(), time.Now(), 1, 1.5).
+			AddRow(1, "Makan Ikan", "Content 2", 1, time.Now(), time.Now(), 1, 0.5)
+		mock.ExpectQuery(query).WithArgs("ayam", "ayam", int64(2), int64(2)).WillReturnRows(rows)
+		a := article.NewMysqlArticleRepository(db)
+
+		list, nextCursor, err := a.Search(context.TODO(), "ayam", repository.EncodeOffsetCursor(2), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, repository.EncodeOffsetCursor(4), nextCursor)
+		assert.Len(t, list, 2)
+		assert.Equal(t, int64(2), list[0].ID)
+		assert.Equal(t, 1.5, list[0].Score)
+		assert.Equal(t, int64(1), list[0].Author.ID)
+	})
+
+	t.Run("error-bad-cursor", func(t *testing.T) {
+		a := article.NewMysqlArticleRepository(db)
+
+		_, _, err := a.Search(context.TODO(), "ayam", "not a cursor", 2)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
 }
@@ -2,6 +2,8 @@ package author_test
 
 import (
     "context"
+    "github.com/tolbier/go-clean-arch/domain"
+    "github.com/tolbier/go-clean-arch/domain/entities"
     "github.com/tolbier/go-clean-arch/repository/mysql/author"
     "testing"
     "time"
@@ -31,3 +33,168 @@ func TestGetByID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, anArticle)
 }
+
+func TestGetByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"})
+
+	query := "SELECT id, name, created_at, updated_at FROM author WHERE id=\\?"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(int64(9)).WillReturnRows(rows)
+
+	a := author.NewMysqlAuthorRepository(db)
+
+	_, err = a.GetByID(context.TODO(), 9)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+		AddRow(1, "Iman Tumorang", time.Now(), time.Now()).
+		AddRow(2, "Bxcodec", time.Now(), time.Now()).
+		AddRow(3, "Tolbier", time.Now(), time.Now())
+
+	query := "SELECT id, name, created_at, updated_at FROM author " +
+		"WHERE \\(created_at > \\? OR \\(created_at = \\? AND id > \\?\\)\\) ORDER BY created_at ASC, id ASC LIMIT \\?"
+
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(0), int64(3)).WillReturnRows(rows)
+	a := author.NewMysqlAuthorRepository(db)
+
+	list, nextCursor, err := a.Fetch(context.TODO(), "", 2)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nextCursor)
+	assert.Len(t, list, 2)
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	au := &entities.Author{
+		Name:      "Iman Tumorang",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT  author SET name=\\? , created_at=\\? , updated_at=\\?"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(au.Name, au.CreatedAt, au.UpdatedAt).WillReturnResult(sqlmock.NewResult(2, 1))
+
+	a := author.NewMysqlAuthorRepository(db)
+
+	err = a.Store(context.TODO(), au)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), au.ID)
+}
+
+func TestUpdate(t *testing.T) {
+	au := &entities.Author{
+		ID:        2,
+		Name:      "Iman Tumorang",
+		UpdatedAt: time.Now(),
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE author set name=\\?, updated_at=\\? WHERE id = \\?"
+	a := author.NewMysqlAuthorRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(au.Name, au.UpdatedAt, au.ID).WillReturnResult(sqlmock.NewResult(2, 1))
+
+		err = a.Update(context.TODO(), au)
+		assert.NoError(t, err)
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		// MySQL reports no row affected when the values are the same
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(au.Name, au.UpdatedAt, au.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = a.Update(context.TODO(), au)
+		assert.NoError(t, err)
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "DELETE FROM author WHERE id = \\? AND NOT EXISTS \\(SELECT 1 FROM article WHERE author_id = \\?\\)"
+	a := author.NewMysqlAuthorRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(2, 2).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		err = a.Delete(context.TODO(), 2, 0)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("reassign", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE article SET author_id = \\?, version = version \\+ 1 WHERE author_id = \\?").
+			WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(query).WithArgs(2, 2).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		err = a.Delete(context.TODO(), 2, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("has-articles", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(2, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM author WHERE id = \\?").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		err = a.Delete(context.TODO(), 2, 0)
+		assert.Equal(t, domain.ErrAuthorHasArticles, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+		AddRow(1, "Iman Tumorang", time.Now(), time.Now()).
+		AddRow(3, "Bxcodec", time.Now(), time.Now())
+
+	query := "SELECT id, name, created_at, updated_at FROM author WHERE id IN \\(\\?,\\?,\\?\\)"
+
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(2), int64(3)).WillReturnRows(rows)
+	a := author.NewMysqlAuthorRepository(db)
+
+	res, err := a.GetByIDs(context.TODO(), []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "Bxcodec", res[3].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	res, err = a.GetByIDs(context.TODO(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
@@ -0,0 +1,239 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+type mysqlCategoryRepository struct {
+	Conn *sql.DB
+}
+
+// NewMysqlCategoryRepository will create an object that represent the category.Repository interface
+func NewMysqlCategoryRepository(Conn *sql.DB) repositories.CategoryRepository {
+	return &mysqlCategoryRepository{Conn}
+}
+
+func (m *mysqlCategoryRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.Category, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.Category, 0)
+	for rows.Next() {
+		t := entities.Category{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.Tag,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlCategoryRepository) getOne(ctx context.Context, query string, args ...interface{}) (res entities.Category, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return entities.Category{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *mysqlCategoryRepository) Fetch(ctx context.Context) ([]entities.Category, error) {
+	query := `SELECT id, name, tag, updated_at, created_at FROM category ORDER BY id`
+	return m.fetch(ctx, query)
+}
+
+func (m *mysqlCategoryRepository) GetByID(ctx context.Context, id int64) (entities.Category, error) {
+	query := `SELECT id, name, tag, updated_at, created_at FROM category WHERE id = ?`
+	return m.getOne(ctx, query, id)
+}
+
+func (m *mysqlCategoryRepository) GetByTag(ctx context.Context, tag string) (entities.Category, error) {
+	query := `SELECT id, name, tag, updated_at, created_at FROM category WHERE tag = ?`
+	return m.getOne(ctx, query, tag)
+}
+
+func (m *mysqlCategoryRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (res map[int64][]entities.Category, err error) {
+	res = make(map[int64][]entities.Category)
+	if len(articleIDs) == 0 {
+		return
+	}
+
+	args := make([]interface{}, len(articleIDs))
+	for i, id := range articleIDs {
+		args[i] = id
+	}
+	query := `SELECT ac.article_id, c.id, c.name, c.tag, c.updated_at, c.created_at
+  						FROM category c JOIN article_category ac ON ac.category_id = c.id
+  						WHERE ac.article_id IN (?` + strings.Repeat(",?", len(articleIDs)-1) + `) ORDER BY c.id`
+
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		articleID := int64(0)
+		t := entities.Category{}
+		err = rows.Scan(
+			&articleID,
+			&t.ID,
+			&t.Name,
+			&t.Tag,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		res[articleID] = append(res[articleID], t)
+	}
+
+	return res, nil
+}
+
+func (m *mysqlCategoryRepository) Store(ctx context.Context, c *entities.Category) (err error) {
+	query := `INSERT  category SET name=? , tag=? , updated_at=? , created_at=?`
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, c.Name, c.Tag, c.UpdatedAt, c.CreatedAt)
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	c.ID = lastID
+	return
+}
+
+func (m *mysqlCategoryRepository) Update(ctx context.Context, c *entities.Category) (err error) {
+	query := `UPDATE category set name=?, tag=?, updated_at=? WHERE ID = ?`
+
+	stmt, err := m.Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, c.Name, c.Tag, c.UpdatedAt, c.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	// MySQL counts the rows changed: an update leaving the category as it was,
+	// within the same second, affects none
+	if affect > 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *mysqlCategoryRepository) Delete(ctx context.Context, id int64) (err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logger.FromContext(ctx).Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM article_category WHERE category_id = ?", id)
+	if err != nil {
+		return
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM category WHERE id = ?", id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (m *mysqlCategoryRepository) Attach(ctx context.Context, articleID int64, categoryID int64) (err error) {
+	query := `INSERT IGNORE article_category SET article_id=? , category_id=?`
+	_, err = m.Conn.ExecContext(ctx, query, articleID, categoryID)
+	return
+}
+
+func (m *mysqlCategoryRepository) Detach(ctx context.Context, articleID int64, categoryID int64) (err error) {
+	query := `DELETE FROM article_category WHERE article_id = ? AND category_id = ?`
+	res, err := m.Conn.ExecContext(ctx, query, articleID, categoryID)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return
+}
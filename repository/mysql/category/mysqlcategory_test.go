@@ -0,0 +1,199 @@
+package category_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/mysql/category"
+)
+
+func TestFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "tag", "updated_at", "created_at"}).
+		AddRow(1, "Makanan", "food", time.Now(), time.Now()).
+		AddRow(2, "Kehidupan", "life", time.Now(), time.Now())
+
+	query := "SELECT id, name, tag, updated_at, created_at FROM category ORDER BY id"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	c := category.NewMysqlCategoryRepository(db)
+
+	list, err := c.Fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func TestGetByTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, name, tag, updated_at, created_at FROM category WHERE tag = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "tag", "updated_at", "created_at"}).
+			AddRow(1, "Makanan", "food", time.Now(), time.Now())
+		mock.ExpectQuery(query).WithArgs("food").WillReturnRows(rows)
+		c := category.NewMysqlCategoryRepository(db)
+
+		res, err := c.GetByTag(context.TODO(), "food")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.ID)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "tag", "updated_at", "created_at"})
+		mock.ExpectQuery(query).WithArgs("travel").WillReturnRows(rows)
+		c := category.NewMysqlCategoryRepository(db)
+
+		_, err := c.GetByTag(context.TODO(), "travel")
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestGetByArticleIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"article_id", "id", "name", "tag", "updated_at", "created_at"}).
+		AddRow(1, 1, "Makanan", "food", time.Now(), time.Now()).
+		AddRow(1, 2, "Kehidupan", "life", time.Now(), time.Now()).
+		AddRow(2, 2, "Kehidupan", "life", time.Now(), time.Now())
+
+	query := "SELECT ac.article_id, c.id, c.name, c.tag, c.updated_at, c.created_at " +
+		"FROM category c JOIN article_category ac ON ac.category_id = c.id " +
+		"WHERE ac.article_id IN \\(\\?,\\?\\) ORDER BY c.id"
+
+	mock.ExpectQuery(query).WithArgs(1, 2).WillReturnRows(rows)
+	c := category.NewMysqlCategoryRepository(db)
+
+	res, err := c.GetByArticleIDs(context.TODO(), []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Len(t, res[1], 2)
+	assert.Len(t, res[2], 1)
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	cat := &entities.Category{
+		Name:      "Makanan",
+		Tag:       "food",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT  category SET name=\\? , tag=\\? , updated_at=\\? , created_at=\\?"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(cat.Name, cat.Tag, cat.UpdatedAt, cat.CreatedAt).WillReturnResult(sqlmock.NewResult(4, 1))
+
+	c := category.NewMysqlCategoryRepository(db)
+
+	err = c.Store(context.TODO(), cat)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), cat.ID)
+}
+
+func TestUpdate(t *testing.T) {
+	cat := &entities.Category{
+		ID:        4,
+		Name:      "Makanan",
+		Tag:       "food",
+		UpdatedAt: time.Now(),
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	c := category.NewMysqlCategoryRepository(db)
+	query := "UPDATE category set name=\\?, tag=\\?, updated_at=\\? WHERE ID = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(cat.Name, cat.Tag, cat.UpdatedAt, cat.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = c.Update(context.TODO(), cat)
+		assert.NoError(t, err)
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		// MySQL reports no row affected when the values are the same
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectExec().WithArgs(cat.Name, cat.Tag, cat.UpdatedAt, cat.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = c.Update(context.TODO(), cat)
+		assert.NoError(t, err)
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM article_category WHERE category_id = \\?").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec("DELETE FROM category WHERE id = \\?").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	c := category.NewMysqlCategoryRepository(db)
+
+	err = c.Delete(context.TODO(), 3)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT IGNORE article_category SET article_id=\\? , category_id=\\?"
+	mock.ExpectExec(query).WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	c := category.NewMysqlCategoryRepository(db)
+
+	err = c.Attach(context.TODO(), 1, 3)
+	assert.NoError(t, err)
+}
+
+func TestDetach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "DELETE FROM article_category WHERE article_id = \\? AND category_id = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		c := category.NewMysqlCategoryRepository(db)
+
+		err := c.Detach(context.TODO(), 1, 3)
+		assert.NoError(t, err)
+	})
+	t.Run("not-attached", func(t *testing.T) {
+		mock.ExpectExec(query).WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(0, 0))
+		c := category.NewMysqlCategoryRepository(db)
+
+		err := c.Detach(context.TODO(), 1, 3)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
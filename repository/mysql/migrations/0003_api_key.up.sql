@@ -0,0 +1,14 @@
+-- API keys of the machine clients, only their SHA-256 is stored.
+
+CREATE TABLE IF NOT EXISTS `api_key` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) COLLATE utf8_unicode_ci NOT NULL,
+  `prefix` varchar(32) COLLATE utf8_unicode_ci NOT NULL,
+  `hash` char(64) COLLATE utf8_unicode_ci NOT NULL,
+  `scopes` varchar(255) COLLATE utf8_unicode_ci NOT NULL DEFAULT '',
+  `author_id` int(11) NOT NULL DEFAULT '0',
+  `created_at` datetime NOT NULL,
+  `revoked_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `api_key_prefix` (`prefix`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
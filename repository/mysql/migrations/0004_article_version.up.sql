@@ -0,0 +1,3 @@
+-- The version of the articles, checked by the conditional writes.
+
+ALTER TABLE `article` ADD COLUMN `version` int(11) NOT NULL DEFAULT '1';
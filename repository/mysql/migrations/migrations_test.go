@@ -0,0 +1,79 @@
+package migrations_test
+
+import (
+	"context"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/lib/migrate"
+	"github.com/tolbier/go-clean-arch/repository/mysql/migrations"
+)
+
+// createTable matches the table definitions of a mysqldump file
+var createTable = regexp.MustCompile("(?s)CREATE TABLE `\\w+` \\(.*?\\) ENGINE=[^;]*;")
+
+// TestBaselineMatchesDump checks the baseline creates the tables exactly as the
+// former article.sql dump did, kept in testdata: on a database where the dump
+// was loaded, the baseline skips them and the following migrations must find
+// the same schema as on a new database.
+func TestBaselineMatchesDump(t *testing.T) {
+	dump, err := ioutil.ReadFile("testdata/article.sql")
+	require.NoError(t, err)
+	baseline, err := migrations.FS.ReadFile("0001_baseline.up.sql")
+	require.NoError(t, err)
+
+	tables := createTable.FindAllString(string(dump), -1)
+	require.Len(t, tables, 4)
+	for _, table := range tables {
+		assert.Contains(t, string(baseline), strings.Replace(table, "CREATE TABLE", "CREATE TABLE IF NOT EXISTS", 1))
+	}
+}
+
+// TestUpFromDump checks every migration is applied to a database where the
+// article.sql dump was loaded, the columns added since included
+func TestUpFromDump(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")).
+		WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(1))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}))
+
+	expectMigration := func(version int64, name string, statements ...string) {
+		mock.ExpectBegin()
+		for _, stmt := range statements {
+			mock.ExpectExec(regexp.QuoteMeta(stmt)).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+			WithArgs(version, name, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	// the tables of the dump exist, the baseline leaves them as they are
+	expectMigration(1, "baseline",
+		"CREATE TABLE IF NOT EXISTS `article`", "INSERT IGNORE INTO `article`",
+		"CREATE TABLE IF NOT EXISTS `article_category`", "INSERT IGNORE INTO `article_category`",
+		"CREATE TABLE IF NOT EXISTS `author`", "INSERT IGNORE INTO `author`",
+		"CREATE TABLE IF NOT EXISTS `category`", "INSERT IGNORE INTO `category`",
+	)
+	expectMigration(2, "article_fulltext", "ALTER TABLE `article` ADD FULLTEXT INDEX `article_fulltext`")
+	expectMigration(3, "api_key", "CREATE TABLE IF NOT EXISTS `api_key`")
+	expectMigration(4, "article_version", "ALTER TABLE `article` ADD COLUMN `version` int(11) NOT NULL DEFAULT '1'")
+	mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	m, err := migrate.New(db, migrate.MySQL, migrations.FS)
+	require.NoError(t, err)
+	applied, err := m.Up(context.TODO())
+	require.NoError(t, err)
+
+	assert.Len(t, applied, 4)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
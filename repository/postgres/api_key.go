@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+type postgresAPIKeyRepository struct {
+	Conn *sql.DB
+}
+
+// NewPostgresAPIKeyRepository will create an object that represent the apikey.Repository interface
+func NewPostgresAPIKeyRepository(Conn *sql.DB) repositories.APIKeyRepository {
+	return &postgresAPIKeyRepository{Conn}
+}
+
+func (m *postgresAPIKeyRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.APIKey, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.APIKey, 0)
+	for rows.Next() {
+		t := entities.APIKey{}
+		scopes := ""
+		revokedAt := sql.NullTime{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.Prefix,
+			&t.Hash,
+			&scopes,
+			&t.AuthorID,
+			&t.CreatedAt,
+			&revokedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		t.Scopes = strings.Fields(scopes)
+		if revokedAt.Valid {
+			t.RevokedAt = &revokedAt.Time
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *postgresAPIKeyRepository) getOne(ctx context.Context, query string, args ...interface{}) (res entities.APIKey, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return entities.APIKey{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *postgresAPIKeyRepository) Fetch(ctx context.Context) ([]entities.APIKey, error) {
+	query := `SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key ORDER BY id`
+	return m.fetch(ctx, query)
+}
+
+func (m *postgresAPIKeyRepository) GetByID(ctx context.Context, id int64) (entities.APIKey, error) {
+	query := `SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key WHERE id = $1`
+	return m.getOne(ctx, query, id)
+}
+
+func (m *postgresAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (entities.APIKey, error) {
+	query := `SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key WHERE prefix = $1`
+	return m.getOne(ctx, query, prefix)
+}
+
+func (m *postgresAPIKeyRepository) Store(ctx context.Context, k *entities.APIKey) error {
+	query := `INSERT INTO api_key (name, prefix, hash, scopes, author_id, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	return m.Conn.QueryRowContext(ctx, query, k.Name, k.Prefix, k.Hash, strings.Join(k.Scopes, " "), k.AuthorID, k.CreatedAt).Scan(&k.ID)
+}
+
+func (m *postgresAPIKeyRepository) Revoke(ctx context.Context, id int64, revokedAt time.Time) (err error) {
+	query := `UPDATE api_key SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+	res, err := m.Conn.ExecContext(ctx, query, revokedAt, id)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return
+}
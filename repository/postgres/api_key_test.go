@@ -0,0 +1,75 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/postgres"
+)
+
+var apiKeyColumns = []string{"id", "name", "prefix", "hash", "scopes", "author_id", "created_at", "revoked_at"}
+
+func TestAPIKeyGetByPrefix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key WHERE prefix = \\$1"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(apiKeyColumns).
+			AddRow(1, "importer", "0001", "h1", "articles:write", 2, time.Now(), nil)
+		mock.ExpectQuery(query).WithArgs("0001").WillReturnRows(rows)
+		k := postgres.NewPostgresAPIKeyRepository(db)
+
+		res, err := k.GetByPrefix(context.TODO(), "0001")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{entities.ScopeArticlesWrite}, res.Scopes)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs("0002").WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+		k := postgres.NewPostgresAPIKeyRepository(db)
+
+		_, err := k.GetByPrefix(context.TODO(), "0002")
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestAPIKeyStore(t *testing.T) {
+	now := time.Now()
+	key := &entities.APIKey{Name: "importer", Prefix: "0001", Hash: "h1", Scopes: []string{entities.ScopeArticlesRead}, CreatedAt: now}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT INTO api_key \\(name, prefix, hash, scopes, author_id, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6\\) RETURNING id"
+	mock.ExpectQuery(query).WithArgs(key.Name, key.Prefix, key.Hash, "articles:read", key.AuthorID, key.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	k := postgres.NewPostgresAPIKeyRepository(db)
+
+	err = k.Store(context.TODO(), key)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), key.ID)
+}
+
+func TestAPIKeyRevoke(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	mock.ExpectExec("UPDATE api_key SET revoked_at = \\$1 WHERE id = \\$2 AND revoked_at IS NULL").
+		WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	k := postgres.NewPostgresAPIKeyRepository(db)
+
+	assert.Equal(t, domain.ErrNotFound, k.Revoke(context.TODO(), 7, now))
+}
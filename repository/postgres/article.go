@@ -0,0 +1,285 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+	"github.com/tolbier/go-clean-arch/lib/search"
+)
+
+type postgresArticleRepository struct {
+	Conn *sql.DB
+}
+
+// NewPostgresArticleRepository will create an object that represent the article.Repository interface
+func NewPostgresArticleRepository(Conn *sql.DB) repositories.ArticleRepository {
+	return &postgresArticleRepository{Conn}
+}
+
+func (m *postgresArticleRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.Article, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.Article, 0)
+	for rows.Next() {
+		t := entities.Article{}
+		authorID := int64(0)
+		err = rows.Scan(
+			&t.ID,
+			&t.Title,
+			&t.Content,
+			&authorID,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.Version,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		t.Author = entities.Author{
+			ID: authorID,
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// fetchPage will run query on the page of the listing described by cursor. The query
+// formats the keyset comparison as %[1]s and the sort order as %[2]s, and takes the
+// cursor position and the limit as its last arguments.
+func (m *postgresArticleRepository) fetchPage(ctx context.Context, query string, cursor string, num int64,
+	args ...interface{}) (res []entities.Article, nextCursor string, prevCursor string, err error) {
+	decodedCursor, err := repository.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", "", domain.ErrBadParamInput
+	}
+
+	op, order := decodedCursor.Keyset()
+	createdAt, id := decodedCursor.Position()
+	args = append(args, createdAt, id, num+1)
+	res, err = m.fetch(ctx, fmt.Sprintf(query, op, order), args...)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	n, nextCursor, prevCursor := repository.Paginate(decodedCursor, len(res), num, func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	}, func(i int) (time.Time, int64) {
+		return res[i].CreatedAt, res[i].ID
+	})
+	return res[:n], nextCursor, prevCursor, nil
+}
+
+func (m *postgresArticleRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, string, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE (created_at, id) %[1]s ($1, $2) ORDER BY created_at %[2]s, id %[2]s LIMIT $3`
+
+	return m.fetchPage(ctx, query, cursor, num)
+}
+
+func (m *postgresArticleRepository) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) ([]entities.Article,
+	string, string, error) {
+	query := `SELECT a.id, a.title, a.content, a.author_id, a.updated_at, a.created_at, a.version
+  						FROM article a
+  						JOIN article_category ac ON ac.article_id = a.id
+  						JOIN category c ON c.id = ac.category_id
+  						WHERE c.tag = $1 AND (a.created_at, a.id) %[1]s ($2, $3)
+  						ORDER BY a.created_at %[2]s, a.id %[2]s LIMIT $4`
+
+	return m.fetchPage(ctx, query, cursor, num, tag)
+}
+
+func (m *postgresArticleRepository) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) ([]entities.Article,
+	string, string, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE author_id = $1 AND (created_at, id) %[1]s ($2, $3)
+  						ORDER BY created_at %[2]s, id %[2]s LIMIT $4`
+
+	return m.fetchPage(ctx, query, cursor, num, authorID)
+}
+
+func (m *postgresArticleRepository) getOne(ctx context.Context, query string, args ...interface{}) (res entities.Article, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return entities.Article{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *postgresArticleRepository) GetByID(ctx context.Context, id int64) (entities.Article, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE id = $1`
+
+	return m.getOne(ctx, query, id)
+}
+
+func (m *postgresArticleRepository) GetByTitle(ctx context.Context, title string) (entities.Article, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE title = $1`
+
+	return m.getOne(ctx, query, title)
+}
+
+func (m *postgresArticleRepository) Search(ctx context.Context, query string, cursor string, num int64) (res []entities.ArticleMatch,
+	nextCursor string, err error) {
+	// any of the terms matches, as the natural language mode of MySQL does,
+	// the expression is the one of the article_fulltext index of the migrations
+	sqlQuery := `SELECT id, title, content, author_id, updated_at, created_at, version,
+  						ts_rank(to_tsvector('simple', title || ' ' || content), to_tsquery('simple', $1)) AS score
+  						FROM article WHERE to_tsvector('simple', title || ' ' || content) @@ to_tsquery('simple', $1)
+  						ORDER BY score DESC, id DESC LIMIT $2 OFFSET $3`
+
+	offset, err := repository.DecodeOffsetCursor(cursor)
+	if err != nil {
+		return nil, "", domain.ErrBadParamInput
+	}
+
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return []entities.ArticleMatch{}, "", nil
+	}
+
+	rows, err := m.Conn.QueryContext(ctx, sqlQuery, strings.Join(terms, " | "), num, offset)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, "", err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	res = make([]entities.ArticleMatch, 0)
+	for rows.Next() {
+		t := entities.ArticleMatch{}
+		authorID := int64(0)
+		err = rows.Scan(
+			&t.ID,
+			&t.Title,
+			&t.Content,
+			&authorID,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.Version,
+			&t.Score,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, "", err
+		}
+		t.Author = entities.Author{
+			ID: authorID,
+		}
+		res = append(res, t)
+	}
+
+	if len(res) == int(num) {
+		nextCursor = repository.EncodeOffsetCursor(offset + num)
+	}
+
+	return
+}
+
+func (m *postgresArticleRepository) Store(ctx context.Context, a *entities.Article) (err error) {
+	query := `INSERT INTO article (title, content, author_id, updated_at, created_at, version)
+  						VALUES ($1, $2, $3, $4, $5, 1) RETURNING id`
+
+	err = m.Conn.QueryRowContext(ctx, query, a.Title, a.Content, a.Author.ID, a.UpdatedAt, a.CreatedAt).Scan(&a.ID)
+	if err != nil {
+		return
+	}
+	a.Version = 1
+	return
+}
+
+func (m *postgresArticleRepository) Delete(ctx context.Context, id int64, version int64) (err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logger.FromContext(ctx).Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM article WHERE id = $1 AND version = $2", id, version)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
+	if rowsAfected != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM article_category WHERE article_id = $1", id)
+	return
+}
+
+func (m *postgresArticleRepository) Update(ctx context.Context, ar *entities.Article) (err error) {
+	query := `UPDATE article SET title = $1, content = $2, author_id = $3, updated_at = $4, version = version + 1
+  						WHERE id = $5 AND version = $6`
+
+	res, err := m.Conn.ExecContext(ctx, query, ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID, ar.Version)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	ar.Version++
+	return
+}
@@ -0,0 +1,310 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+	"github.com/tolbier/go-clean-arch/repository/postgres"
+)
+
+var articleColumns = []string{"id", "title", "content", "author_id", "updated_at", "created_at", "version"}
+
+func TestArticleFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows(articleColumns).
+		AddRow(2, "title 2", "content 2", 1, now, now, 1).
+		AddRow(3, "title 3", "content 3", 1, now, now, 1).
+		AddRow(4, "title 4", "content 4", 1, now, now, 1)
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, version FROM article " +
+		"WHERE \\(created_at, id\\) > \\(\\$1, \\$2\\) ORDER BY created_at ASC, id ASC LIMIT \\$3"
+
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), int64(1), int64(3)).WillReturnRows(rows)
+	a := postgres.NewPostgresArticleRepository(db)
+	cursor := repository.EncodeCursor(repository.Cursor{CreatedAt: now, ID: 1})
+	list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, 2)
+	assert.NotEmpty(t, nextCursor)
+	assert.NotEmpty(t, prevCursor)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func TestArticleFetchDesc(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows(articleColumns).
+		AddRow(2, "title 2", "content 2", 1, now, now, 1).
+		AddRow(1, "title 1", "content 1", 1, now, now, 1)
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, version FROM article " +
+		"WHERE \\(created_at, id\\) < \\(\\$1, \\$2\\) ORDER BY created_at DESC, id DESC LIMIT \\$3"
+
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), int64(0), int64(3)).WillReturnRows(rows)
+	a := postgres.NewPostgresArticleRepository(db)
+	list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), repository.StartCursor(true), 2)
+	assert.NoError(t, err)
+	assert.Empty(t, nextCursor)
+	assert.Empty(t, prevCursor)
+	assert.Len(t, list, 2)
+	assert.Equal(t, int64(2), list[0].ID)
+}
+
+func TestArticleFetchBadCursor(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	a := postgres.NewPostgresArticleRepository(db)
+	_, _, _, err = a.Fetch(context.TODO(), "not-a-cursor", 2)
+	assert.Equal(t, domain.ErrBadParamInput, err)
+}
+
+func TestArticleFetchByCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows(articleColumns).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), 1)
+
+	query := "SELECT a.id, a.title, a.content, a.author_id, a.updated_at, a.created_at, a.version FROM article a " +
+		"JOIN article_category ac ON ac.article_id = a.id JOIN category c ON c.id = ac.category_id " +
+		"WHERE c.tag = \\$1 AND \\(a.created_at, a.id\\) > \\(\\$2, \\$3\\) ORDER BY a.created_at ASC, a.id ASC LIMIT \\$4"
+
+	mock.ExpectQuery(query).WithArgs("food", sqlmock.AnyArg(), int64(0), int64(3)).WillReturnRows(rows)
+	a := postgres.NewPostgresArticleRepository(db)
+
+	list, nextCursor, _, err := a.FetchByCategory(context.TODO(), "food", "", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, nextCursor)
+	assert.Len(t, list, 1)
+}
+
+func TestArticleFetchByAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows(articleColumns).
+		AddRow(1, "title 1", "Content 1", 3, time.Now(), time.Now(), 1)
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, version FROM article " +
+		"WHERE author_id = \\$1 AND \\(created_at, id\\) > \\(\\$2, \\$3\\) ORDER BY created_at ASC, id ASC LIMIT \\$4"
+
+	mock.ExpectQuery(query).WithArgs(int64(3), sqlmock.AnyArg(), int64(0), int64(11)).WillReturnRows(rows)
+	a := postgres.NewPostgresArticleRepository(db)
+
+	list, _, _, err := a.FetchByAuthor(context.TODO(), 3, "", 10)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, int64(3), list[0].Author.ID)
+}
+
+func TestArticleGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, version FROM article WHERE id = \\$1"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(5, "title 1", "Content 1", 1, time.Now(), time.Now(), 2)
+		mock.ExpectQuery(query).WithArgs(int64(5)).WillReturnRows(rows)
+		a := postgres.NewPostgresArticleRepository(db)
+
+		anArticle, err := a.GetByID(context.TODO(), 5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), anArticle.ID)
+		assert.Equal(t, int64(2), anArticle.Version)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(int64(9)).WillReturnRows(sqlmock.NewRows(articleColumns))
+		a := postgres.NewPostgresArticleRepository(db)
+
+		_, err := a.GetByID(context.TODO(), 9)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestArticleGetByTitle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows(articleColumns).
+		AddRow(1, "title 1", "Content 1", 1, time.Now(), time.Now(), 1)
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, version FROM article WHERE title = \\$1"
+
+	mock.ExpectQuery(query).WithArgs("title 1").WillReturnRows(rows)
+	a := postgres.NewPostgresArticleRepository(db)
+
+	anArticle, err := a.GetByTitle(context.TODO(), "title 1")
+	assert.NoError(t, err)
+	assert.Equal(t, "title 1", anArticle.Title)
+}
+
+func TestArticleStore(t *testing.T) {
+	now := time.Now()
+	ar := &entities.Article{
+		Title:     "Judul",
+		Content:   "Content",
+		CreatedAt: now,
+		UpdatedAt: now,
+		Author: entities.Author{
+			ID:   1,
+			Name: "Iman Tumorang",
+		},
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT INTO article \\(title, content, author_id, updated_at, created_at, version\\) " +
+		"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, 1\\) RETURNING id"
+	mock.ExpectQuery(query).
+		WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+	a := postgres.NewPostgresArticleRepository(db)
+
+	err = a.Store(context.TODO(), ar)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), ar.ID)
+	assert.Equal(t, int64(1), ar.Version)
+}
+
+func TestArticleDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "DELETE FROM article WHERE id = \\$1 AND version = \\$2"
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(12, 3).WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectExec("DELETE FROM article_category WHERE article_id = \\$1").WithArgs(12).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		a := postgres.NewPostgresArticleRepository(db)
+
+		err := a.Delete(context.TODO(), 12, 3)
+		assert.NoError(t, err)
+	})
+
+	t.Run("version-mismatch", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(12, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		a := postgres.NewPostgresArticleRepository(db)
+
+		err := a.Delete(context.TODO(), 12, 2)
+		assert.Equal(t, domain.ErrPreconditionFailed, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUpdate(t *testing.T) {
+	now := time.Now()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE article SET title = \\$1, content = \\$2, author_id = \\$3, updated_at = \\$4, version = version \\+ 1 " +
+		"WHERE id = \\$5 AND version = \\$6"
+
+	t.Run("success", func(t *testing.T) {
+		ar := &entities.Article{
+			ID: 12, Title: "Judul", Content: "Content", UpdatedAt: now,
+			Author: entities.Author{ID: 1}, Version: 2,
+		}
+		mock.ExpectExec(query).WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID, ar.Version).
+			WillReturnResult(sqlmock.NewResult(12, 1))
+
+		a := postgres.NewPostgresArticleRepository(db)
+
+		err := a.Update(context.TODO(), ar)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), ar.Version)
+	})
+
+	t.Run("version-mismatch", func(t *testing.T) {
+		ar := &entities.Article{
+			ID: 12, Title: "Judul", Content: "Content", UpdatedAt: now,
+			Author: entities.Author{ID: 1}, Version: 1,
+		}
+		mock.ExpectExec(query).WithArgs(ar.Title, ar.Content, ar.Author.ID, ar.UpdatedAt, ar.ID, ar.Version).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		a := postgres.NewPostgresArticleRepository(db)
+
+		err := a.Update(context.TODO(), ar)
+		assert.Equal(t, domain.ErrPreconditionFailed, err)
+		assert.Equal(t, int64(1), ar.Version)
+	})
+}
+
+func TestArticleSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, title, content, author_id, updated_at, created_at, version, " +
+		"ts_rank\\(to_tsvector\\('simple', title \\|\\| ' ' \\|\\| content\\), to_tsquery\\('simple', \\$1\\)\\) AS score " +
+		"FROM article WHERE to_tsvector\\('simple', title \\|\\| ' ' \\|\\| content\\) @@ to_tsquery\\('simple', \\$1\\) " +
+		"ORDER BY score DESC, id DESC LIMIT \\$2 OFFSET \\$3"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(append(articleColumns, "score")).
+			AddRow(2, "Makan Ayam", "Content 1", 1, time.Now(), time.Now(), 1, 0.6)
+		mock.ExpectQuery(query).WithArgs("makan | ayam", int64(2), int64(0)).WillReturnRows(rows)
+		a := postgres.NewPostgresArticleRepository(db)
+
+		list, nextCursor, err := a.Search(context.TODO(), "Makan ayam!", "", 2)
+		assert.NoError(t, err)
+		assert.Empty(t, nextCursor)
+		assert.Len(t, list, 1)
+		assert.Equal(t, 0.6, list[0].Score)
+	})
+
+	t.Run("no-terms", func(t *testing.T) {
+		a := postgres.NewPostgresArticleRepository(db)
+
+		list, _, err := a.Search(context.TODO(), "!!", "", 2)
+		assert.NoError(t, err)
+		assert.Len(t, list, 0)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+)
+
+type postgresAuthorRepo struct {
+	DB *sql.DB
+}
+
+// NewPostgresAuthorRepository will create an implementation of author.Repository
+func NewPostgresAuthorRepository(db *sql.DB) repositories.AuthorRepository {
+	return &postgresAuthorRepo{
+		DB: db,
+	}
+}
+
+func (m *postgresAuthorRepo) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.Author, err error) {
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.Author, 0)
+	for rows.Next() {
+		t := entities.Author{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *postgresAuthorRepo) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Author, nextCursor string, err error) {
+	query := `SELECT id, name, created_at, updated_at FROM author
+  						WHERE (created_at, id) %[1]s ($1, $2) ORDER BY created_at %[2]s, id %[2]s LIMIT $3`
+
+	decodedCursor, err := repository.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", domain.ErrBadParamInput
+	}
+
+	op, order := decodedCursor.Keyset()
+	createdAt, id := decodedCursor.Position()
+	res, err = m.fetch(ctx, fmt.Sprintf(query, op, order), createdAt, id, num+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	n, nextCursor, _ := repository.Paginate(decodedCursor, len(res), num, func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	}, func(i int) (time.Time, int64) {
+		return res[i].CreatedAt, res[i].ID
+	})
+	return res[:n], nextCursor, nil
+}
+
+func (m *postgresAuthorRepo) GetByID(ctx context.Context, id int64) (entities.Author, error) {
+	query := `SELECT id, name, created_at, updated_at FROM author WHERE id = $1`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return entities.Author{}, err
+	}
+	if len(list) == 0 {
+		return entities.Author{}, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *postgresAuthorRepo) GetByIDs(ctx context.Context, ids []int64) (map[int64]entities.Author, error) {
+	res := make(map[int64]entities.Author)
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	query := `SELECT id, name, created_at, updated_at FROM author WHERE id = ANY($1)`
+
+	list, err := m.fetch(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range list {
+		res[a.ID] = a
+	}
+	return res, nil
+}
+
+func (m *postgresAuthorRepo) Store(ctx context.Context, a *entities.Author) error {
+	query := `INSERT INTO author (name, created_at, updated_at) VALUES ($1, $2, $3) RETURNING id`
+
+	return m.DB.QueryRowContext(ctx, query, a.Name, a.CreatedAt, a.UpdatedAt).Scan(&a.ID)
+}
+
+func (m *postgresAuthorRepo) Update(ctx context.Context, a *entities.Author) (err error) {
+	query := `UPDATE author SET name = $1, updated_at = $2 WHERE id = $3`
+
+	res, err := m.DB.ExecContext(ctx, query, a.Name, a.UpdatedAt, a.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresAuthorRepo) Delete(ctx context.Context, id int64, reassignTo int64) (err error) {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logger.FromContext(ctx).Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if reassignTo != 0 {
+		_, err = tx.ExecContext(ctx, "UPDATE article SET author_id = $1, version = version + 1 WHERE author_id = $2", reassignTo, id)
+		if err != nil {
+			return
+		}
+	}
+
+	// the articles are looked for by the statement deleting the author, rather
+	// than ahead of it, for none to be left pointing to a deleted author
+	res, err := tx.ExecContext(ctx, "DELETE FROM author WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM article WHERE author_id = $1)", id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected == 0 {
+		var count int
+		if err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM author WHERE id = $1", id).Scan(&count); err != nil {
+			return
+		}
+		err = domain.ErrAuthorHasArticles
+		if count == 0 {
+			err = domain.ErrNotFound
+		}
+		return
+	}
+	if rowsAfected != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,164 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/postgres"
+)
+
+var authorColumns = []string{"id", "name", "created_at", "updated_at"}
+
+func TestAuthorGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, name, created_at, updated_at FROM author WHERE id = \\$1"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(authorColumns).AddRow(1, "Iman Tumorang", time.Now(), time.Now())
+		mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnRows(rows)
+
+		a := postgres.NewPostgresAuthorRepository(db)
+
+		anAuthor, err := a.GetByID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Iman Tumorang", anAuthor.Name)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(int64(9)).WillReturnRows(sqlmock.NewRows(authorColumns))
+
+		a := postgres.NewPostgresAuthorRepository(db)
+
+		_, err := a.GetByID(context.TODO(), 9)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestAuthorFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows(authorColumns).
+		AddRow(1, "Iman Tumorang", time.Now(), time.Now()).
+		AddRow(2, "Bxcodec", time.Now(), time.Now()).
+		AddRow(3, "Tolbier", time.Now(), time.Now())
+
+	query := "SELECT id, name, created_at, updated_at FROM author " +
+		"WHERE \\(created_at, id\\) > \\(\\$1, \\$2\\) ORDER BY created_at ASC, id ASC LIMIT \\$3"
+
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), int64(0), int64(3)).WillReturnRows(rows)
+	a := postgres.NewPostgresAuthorRepository(db)
+
+	list, nextCursor, err := a.Fetch(context.TODO(), "", 2)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nextCursor)
+	assert.Len(t, list, 2)
+}
+
+func TestAuthorStore(t *testing.T) {
+	now := time.Now()
+	au := &entities.Author{
+		Name:      "Iman Tumorang",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT INTO author \\(name, created_at, updated_at\\) VALUES \\(\\$1, \\$2, \\$3\\) RETURNING id"
+	mock.ExpectQuery(query).WithArgs(au.Name, au.CreatedAt, au.UpdatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	a := postgres.NewPostgresAuthorRepository(db)
+
+	err = a.Store(context.TODO(), au)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), au.ID)
+}
+
+func TestAuthorUpdate(t *testing.T) {
+	au := &entities.Author{
+		ID:        2,
+		Name:      "Iman Tumorang",
+		UpdatedAt: time.Now(),
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE author SET name = \\$1, updated_at = \\$2 WHERE id = \\$3"
+	mock.ExpectExec(query).WithArgs(au.Name, au.UpdatedAt, au.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	a := postgres.NewPostgresAuthorRepository(db)
+
+	err = a.Update(context.TODO(), au)
+	assert.NoError(t, err)
+}
+
+func TestAuthorDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "DELETE FROM author WHERE id = \\$1 AND NOT EXISTS \\(SELECT 1 FROM article WHERE author_id = \\$1\\)"
+	a := postgres.NewPostgresAuthorRepository(db)
+
+	t.Run("reassign", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE article SET author_id = \\$1, version = version \\+ 1 WHERE author_id = \\$2").
+			WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(query).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = a.Delete(context.TODO(), 2, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM author WHERE id = \\$1").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		err = a.Delete(context.TODO(), 2, 0)
+		assert.Equal(t, domain.ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuthorGetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows(authorColumns).
+		AddRow(1, "Iman Tumorang", time.Now(), time.Now()).
+		AddRow(3, "Bxcodec", time.Now(), time.Now())
+
+	mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM author WHERE id = ANY\\(\\$1\\)").
+		WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+	a := postgres.NewPostgresAuthorRepository(db)
+
+	res, err := a.GetByIDs(context.TODO(), []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "Iman Tumorang", res[1].Name)
+}
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+type postgresCategoryRepository struct {
+	Conn *sql.DB
+}
+
+// NewPostgresCategoryRepository will create an object that represent the category.Repository interface
+func NewPostgresCategoryRepository(Conn *sql.DB) repositories.CategoryRepository {
+	return &postgresCategoryRepository{Conn}
+}
+
+func (m *postgresCategoryRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.Category, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.Category, 0)
+	for rows.Next() {
+		t := entities.Category{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.Tag,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *postgresCategoryRepository) getOne(ctx context.Context, query string, args ...interface{}) (res entities.Category, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return entities.Category{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *postgresCategoryRepository) Fetch(ctx context.Context) ([]entities.Category, error) {
+	query := `SELECT id, name, tag, updated_at, created_at FROM category ORDER BY id`
+	return m.fetch(ctx, query)
+}
+
+func (m *postgresCategoryRepository) GetByID(ctx context.Context, id int64) (entities.Category, error) {
+	query := `SELECT id, name, tag, updated_at, created_at FROM category WHERE id = $1`
+	return m.getOne(ctx, query, id)
+}
+
+func (m *postgresCategoryRepository) GetByTag(ctx context.Context, tag string) (entities.Category, error) {
+	query := `SELECT id, name, tag, updated_at, created_at FROM category WHERE tag = $1`
+	return m.getOne(ctx, query, tag)
+}
+
+func (m *postgresCategoryRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (res map[int64][]entities.Category, err error) {
+	res = make(map[int64][]entities.Category)
+	if len(articleIDs) == 0 {
+		return
+	}
+
+	query := `SELECT ac.article_id, c.id, c.name, c.tag, c.updated_at, c.created_at
+  						FROM category c JOIN article_category ac ON ac.category_id = c.id
+  						WHERE ac.article_id = ANY($1) ORDER BY c.id`
+
+	rows, err := m.Conn.QueryContext(ctx, query, pq.Array(articleIDs))
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		articleID := int64(0)
+		t := entities.Category{}
+		err = rows.Scan(
+			&articleID,
+			&t.ID,
+			&t.Name,
+			&t.Tag,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		res[articleID] = append(res[articleID], t)
+	}
+
+	return res, nil
+}
+
+func (m *postgresCategoryRepository) Store(ctx context.Context, c *entities.Category) error {
+	query := `INSERT INTO category (name, tag, updated_at, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	return m.Conn.QueryRowContext(ctx, query, c.Name, c.Tag, c.UpdatedAt, c.CreatedAt).Scan(&c.ID)
+}
+
+func (m *postgresCategoryRepository) Update(ctx context.Context, c *entities.Category) (err error) {
+	query := `UPDATE category SET name = $1, tag = $2, updated_at = $3 WHERE id = $4`
+
+	res, err := m.Conn.ExecContext(ctx, query, c.Name, c.Tag, c.UpdatedAt, c.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresCategoryRepository) Delete(ctx context.Context, id int64) (err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logger.FromContext(ctx).Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM article_category WHERE category_id = $1", id)
+	if err != nil {
+		return
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM category WHERE id = $1", id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (m *postgresCategoryRepository) Attach(ctx context.Context, articleID int64, categoryID int64) (err error) {
+	query := `INSERT INTO article_category (article_id, category_id) VALUES ($1, $2)
+  						ON CONFLICT (article_id, category_id) DO NOTHING`
+	_, err = m.Conn.ExecContext(ctx, query, articleID, categoryID)
+	return
+}
+
+func (m *postgresCategoryRepository) Detach(ctx context.Context, articleID int64, categoryID int64) (err error) {
+	query := `DELETE FROM article_category WHERE article_id = $1 AND category_id = $2`
+	res, err := m.Conn.ExecContext(ctx, query, articleID, categoryID)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return
+}
@@ -0,0 +1,159 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/postgres"
+)
+
+var categoryColumns = []string{"id", "name", "tag", "updated_at", "created_at"}
+
+func TestCategoryFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows(categoryColumns).
+		AddRow(1, "Makanan", "food", time.Now(), time.Now()).
+		AddRow(2, "Kehidupan", "life", time.Now(), time.Now())
+
+	mock.ExpectQuery("SELECT id, name, tag, updated_at, created_at FROM category ORDER BY id").WillReturnRows(rows)
+	c := postgres.NewPostgresCategoryRepository(db)
+
+	list, err := c.Fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func TestCategoryGetByTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, name, tag, updated_at, created_at FROM category WHERE tag = \\$1"
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows(categoryColumns).AddRow(1, "Makanan", "food", time.Now(), time.Now())
+		mock.ExpectQuery(query).WithArgs("food").WillReturnRows(rows)
+		c := postgres.NewPostgresCategoryRepository(db)
+
+		res, err := c.GetByTag(context.TODO(), "food")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.ID)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs("nope").WillReturnRows(sqlmock.NewRows(categoryColumns))
+		c := postgres.NewPostgresCategoryRepository(db)
+
+		_, err := c.GetByTag(context.TODO(), "nope")
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestCategoryGetByArticleIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"article_id", "id", "name", "tag", "updated_at", "created_at"}).
+		AddRow(1, 1, "Makanan", "food", time.Now(), time.Now()).
+		AddRow(1, 2, "Kehidupan", "life", time.Now(), time.Now()).
+		AddRow(2, 2, "Kehidupan", "life", time.Now(), time.Now())
+
+	query := "SELECT ac.article_id, c.id, c.name, c.tag, c.updated_at, c.created_at " +
+		"FROM category c JOIN article_category ac ON ac.category_id = c.id " +
+		"WHERE ac.article_id = ANY\\(\\$1\\) ORDER BY c.id"
+
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+	c := postgres.NewPostgresCategoryRepository(db)
+
+	res, err := c.GetByArticleIDs(context.TODO(), []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Len(t, res[1], 2)
+	assert.Len(t, res[2], 1)
+}
+
+func TestCategoryStore(t *testing.T) {
+	now := time.Now()
+	cat := &entities.Category{
+		Name:      "Makanan",
+		Tag:       "food",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT INTO category \\(name, tag, updated_at, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) RETURNING id"
+	mock.ExpectQuery(query).WithArgs(cat.Name, cat.Tag, cat.UpdatedAt, cat.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+	c := postgres.NewPostgresCategoryRepository(db)
+
+	err = c.Store(context.TODO(), cat)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), cat.ID)
+}
+
+func TestCategoryDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM article_category WHERE category_id = \\$1").WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM category WHERE id = \\$1").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	c := postgres.NewPostgresCategoryRepository(db)
+
+	err = c.Delete(context.TODO(), 3)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryAttach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT INTO article_category \\(article_id, category_id\\) VALUES \\(\\$1, \\$2\\) " +
+		"ON CONFLICT \\(article_id, category_id\\) DO NOTHING"
+	mock.ExpectExec(query).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	c := postgres.NewPostgresCategoryRepository(db)
+
+	err = c.Attach(context.TODO(), 1, 2)
+	assert.NoError(t, err)
+}
+
+func TestCategoryDetach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "DELETE FROM article_category WHERE article_id = \\$1 AND category_id = \\$2"
+	mock.ExpectExec(query).WithArgs(1, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	c := postgres.NewPostgresCategoryRepository(db)
+
+	err = c.Detach(context.TODO(), 1, 9)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS article_category;
+DROP TABLE IF EXISTS category;
+DROP TABLE IF EXISTS author;
+DROP TABLE IF EXISTS article;
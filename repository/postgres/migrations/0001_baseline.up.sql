@@ -0,0 +1,56 @@
+-- Baseline schema of the article service.
+
+CREATE TABLE IF NOT EXISTS article (
+  id         BIGSERIAL PRIMARY KEY,
+  title      VARCHAR(45) NOT NULL,
+  content    TEXT NOT NULL,
+  author_id  BIGINT DEFAULT 0,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+  version    BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS article_category (
+  id          BIGSERIAL PRIMARY KEY,
+  article_id  BIGINT NOT NULL,
+  category_id BIGINT NOT NULL,
+  CONSTRAINT article_category_composite UNIQUE (article_id, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS author (
+  id         BIGSERIAL PRIMARY KEY,
+  name       VARCHAR(200) DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS category (
+  id         BIGSERIAL PRIMARY KEY,
+  name       VARCHAR(45) NOT NULL,
+  tag        VARCHAR(45) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+INSERT INTO article (id, title, content, author_id, updated_at, created_at, version) VALUES
+  (1, 'Makan Ayam', '<p>But I must explain to you how all this mistaken idea of denouncing pleasure and praising pain was born.</p>', 1, '2017-05-18 13:50:19+07', '2017-05-18 13:50:19+07', 1),
+  (2, 'Makan Ikan', '<h1>Odio Mollis Turpis Dictumst</h1>', 1, '2017-05-18 13:50:19+07', '2017-05-18 13:50:19+07', 1),
+  (3, 'Makan Sayur', 'Lorem ipsum dolor sit amet, consectetur adipiscing elit.', 1, '2017-05-18 13:50:19+07', '2017-05-18 13:50:19+07', 1)
+ON CONFLICT DO NOTHING;
+SELECT setval('article_id_seq', (SELECT MAX(id) FROM article));
+
+INSERT INTO article_category (article_id, category_id) VALUES
+  (1, 1), (1, 2), (1, 3), (2, 1), (2, 2), (2, 3), (3, 3)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO author (id, name, created_at, updated_at) VALUES
+  (1, 'Iman Tumorang', '2017-05-18 13:50:19+07', '2017-05-18 13:50:19+07')
+ON CONFLICT DO NOTHING;
+SELECT setval('author_id_seq', (SELECT MAX(id) FROM author));
+
+INSERT INTO category (id, name, tag, created_at, updated_at) VALUES
+  (1, 'Makanan', 'food', '2017-05-18 13:50:19+07', '2017-05-18 13:50:19+07'),
+  (2, 'Kehidupan', 'life', '2017-05-18 13:50:19+07', '2017-05-18 13:50:19+07'),
+  (3, 'Kasih Sayang', 'love', '2017-05-18 13:50:19+07', '2017-05-18 13:50:19+07')
+ON CONFLICT DO NOTHING;
+SELECT setval('category_id_seq', (SELECT MAX(id) FROM category));
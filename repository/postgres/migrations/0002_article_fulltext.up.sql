@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS article_fulltext ON article USING GIN (to_tsvector('simple', title || ' ' || content));
@@ -0,0 +1,12 @@
+-- API keys of the machine clients, only their SHA-256 is stored.
+
+CREATE TABLE IF NOT EXISTS api_key (
+  id         BIGSERIAL PRIMARY KEY,
+  name       VARCHAR(100) NOT NULL,
+  prefix     VARCHAR(32) NOT NULL UNIQUE,
+  hash       CHAR(64) NOT NULL,
+  scopes     VARCHAR(255) NOT NULL DEFAULT '',
+  author_id  BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  revoked_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+)
+
+type sqliteAPIKeyRepository struct {
+	Conn *sql.DB
+}
+
+// NewSqliteAPIKeyRepository will create an object that represent the apikey.Repository interface
+func NewSqliteAPIKeyRepository(Conn *sql.DB) repositories.APIKeyRepository {
+	return &sqliteAPIKeyRepository{Conn}
+}
+
+func (m *sqliteAPIKeyRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.APIKey, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.APIKey, 0)
+	for rows.Next() {
+		t := entities.APIKey{}
+		scopes := ""
+		revokedAt := sql.NullTime{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.Prefix,
+			&t.Hash,
+			&scopes,
+			&t.AuthorID,
+			&t.CreatedAt,
+			&revokedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		t.Scopes = strings.Fields(scopes)
+		if revokedAt.Valid {
+			t.RevokedAt = &revokedAt.Time
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *sqliteAPIKeyRepository) getOne(ctx context.Context, query string, args ...interface{}) (res entities.APIKey, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return entities.APIKey{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *sqliteAPIKeyRepository) Fetch(ctx context.Context) ([]entities.APIKey, error) {
+	query := `SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key ORDER BY id`
+	return m.fetch(ctx, query)
+}
+
+func (m *sqliteAPIKeyRepository) GetByID(ctx context.Context, id int64) (entities.APIKey, error) {
+	query := `SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key WHERE id = ?`
+	return m.getOne(ctx, query, id)
+}
+
+func (m *sqliteAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (entities.APIKey, error) {
+	query := `SELECT id, name, prefix, hash, scopes, author_id, created_at, revoked_at FROM api_key WHERE prefix = ?`
+	return m.getOne(ctx, query, prefix)
+}
+
+func (m *sqliteAPIKeyRepository) Store(ctx context.Context, k *entities.APIKey) error {
+	query := `INSERT INTO api_key (name, prefix, hash, scopes, author_id, created_at) VALUES (?, ?, ?, ?, ?, ?)`
+
+	res, err := m.Conn.ExecContext(ctx, query, k.Name, k.Prefix, k.Hash, strings.Join(k.Scopes, " "), k.AuthorID, formatTime(k.CreatedAt))
+	if err != nil {
+		return err
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	k.ID = lastID
+	return nil
+}
+
+func (m *sqliteAPIKeyRepository) Revoke(ctx context.Context, id int64, revokedAt time.Time) (err error) {
+	query := `UPDATE api_key SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`
+	res, err := m.Conn.ExecContext(ctx, query, formatTime(revokedAt), id)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		return domain.ErrNotFound
+	}
+	return
+}
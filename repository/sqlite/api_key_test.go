@@ -0,0 +1,56 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/sqlite"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+	k := sqlite.NewSqliteAPIKeyRepository(db)
+	now := time.Now()
+
+	reader := &entities.APIKey{Name: "reader", Prefix: "0001", Hash: "h1", Scopes: []string{entities.ScopeArticlesRead}, CreatedAt: now}
+	writer := &entities.APIKey{Name: "writer", Prefix: "0002", Hash: "h2", Scopes: []string{entities.ScopeArticlesRead, entities.ScopeArticlesWrite}, AuthorID: 1, CreatedAt: now}
+	require.NoError(t, k.Store(context.TODO(), reader))
+	require.NoError(t, k.Store(context.TODO(), writer))
+
+	t.Run("fetch", func(t *testing.T) {
+		list, err := k.Fetch(context.TODO())
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+
+		res, err := k.GetByPrefix(context.TODO(), "0002")
+		require.NoError(t, err)
+		assert.Equal(t, writer.ID, res.ID)
+		assert.Equal(t, "h2", res.Hash)
+		assert.Equal(t, writer.Scopes, res.Scopes)
+		assert.Nil(t, res.RevokedAt)
+
+		_, err = k.GetByPrefix(context.TODO(), "0003")
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+
+	t.Run("duplicate-prefix", func(t *testing.T) {
+		assert.Error(t, k.Store(context.TODO(), &entities.APIKey{Name: "other", Prefix: "0001", Hash: "h3", CreatedAt: now}))
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		require.NoError(t, k.Revoke(context.TODO(), reader.ID, now))
+		assert.Equal(t, domain.ErrNotFound, k.Revoke(context.TODO(), reader.ID, now))
+
+		res, err := k.GetByID(context.TODO(), reader.ID)
+		require.NoError(t, err)
+		require.NotNil(t, res.RevokedAt)
+		assert.Equal(t, now.Unix(), res.RevokedAt.Unix())
+	})
+}
@@ -0,0 +1,260 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+	"github.com/tolbier/go-clean-arch/lib/search"
+)
+
+type sqliteArticleRepository struct {
+	Conn *sql.DB
+}
+
+// NewSqliteArticleRepository will create an object that represent the article.Repository interface
+func NewSqliteArticleRepository(Conn *sql.DB) repositories.ArticleRepository {
+	return &sqliteArticleRepository{Conn}
+}
+
+func (m *sqliteArticleRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.Article, err error) {
+	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.Article, 0)
+	for rows.Next() {
+		t := entities.Article{}
+		authorID := int64(0)
+		err = rows.Scan(
+			&t.ID,
+			&t.Title,
+			&t.Content,
+			&authorID,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.Version,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		t.Author = entities.Author{
+			ID: authorID,
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// fetchPage will run query on the page of the listing described by cursor. The query
+// formats the keyset comparison as %[1]s and the sort order as %[2]s, and takes the
+// cursor position and the limit as its last arguments.
+func (m *sqliteArticleRepository) fetchPage(ctx context.Context, query string, cursor string, num int64,
+	args ...interface{}) (res []entities.Article, nextCursor string, prevCursor string, err error) {
+	decodedCursor, err := repository.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", "", domain.ErrBadParamInput
+	}
+
+	op, order := decodedCursor.Keyset()
+	createdAt, id := decodedCursor.Position()
+	args = append(args, formatTime(createdAt), id, num+1)
+	res, err = m.fetch(ctx, fmt.Sprintf(query, op, order), args...)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	n, nextCursor, prevCursor := repository.Paginate(decodedCursor, len(res), num, func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	}, func(i int) (time.Time, int64) {
+		return res[i].CreatedAt, res[i].ID
+	})
+	return res[:n], nextCursor, prevCursor, nil
+}
+
+func (m *sqliteArticleRepository) Fetch(ctx context.Context, cursor string, num int64) ([]entities.Article, string, string, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE (created_at, id) %[1]s (?, ?) ORDER BY created_at %[2]s, id %[2]s LIMIT ?`
+
+	return m.fetchPage(ctx, query, cursor, num)
+}
+
+func (m *sqliteArticleRepository) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) ([]entities.Article,
+	string, string, error) {
+	query := `SELECT a.id, a.title, a.content, a.author_id, a.updated_at, a.created_at, a.version
+  						FROM article a
+  						JOIN article_category ac ON ac.article_id = a.id
+  						JOIN category c ON c.id = ac.category_id
+  						WHERE c.tag = ? AND (a.created_at, a.id) %[1]s (?, ?)
+  						ORDER BY a.created_at %[2]s, a.id %[2]s LIMIT ?`
+
+	return m.fetchPage(ctx, query, cursor, num, tag)
+}
+
+func (m *sqliteArticleRepository) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) ([]entities.Article,
+	string, string, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE author_id = ? AND (created_at, id) %[1]s (?, ?)
+  						ORDER BY created_at %[2]s, id %[2]s LIMIT ?`
+
+	return m.fetchPage(ctx, query, cursor, num, authorID)
+}
+
+func (m *sqliteArticleRepository) getOne(ctx context.Context, query string, args ...interface{}) (res entities.Article, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return entities.Article{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *sqliteArticleRepository) GetByID(ctx context.Context, id int64) (entities.Article, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE id = ?`
+
+	return m.getOne(ctx, query, id)
+}
+
+func (m *sqliteArticleRepository) GetByTitle(ctx context.Context, title string) (entities.Article, error) {
+	query := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE title = ?`
+
+	return m.getOne(ctx, query, title)
+}
+
+func (m *sqliteArticleRepository) Search(ctx context.Context, query string, cursor string, num int64) ([]entities.ArticleMatch,
+	string, error) {
+	// SQLite is built without a ranking full-text index, the candidates
+	// containing any of the terms are ranked like the in-memory storage does
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return search.Page(nil, cursor, num)
+	}
+
+	conditions := make([]string, 0, len(terms))
+	args := make([]interface{}, 0, 2*len(terms))
+	for _, term := range terms {
+		// a term holds letters and digits only, never a LIKE wildcard
+		conditions = append(conditions, `title LIKE ? OR content LIKE ?`)
+		args = append(args, "%"+term+"%", "%"+term+"%")
+	}
+	sqlQuery := `SELECT id, title, content, author_id, updated_at, created_at, version
+  						FROM article WHERE ` + strings.Join(conditions, " OR ")
+
+	list, err := m.fetch(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	matches := make([]entities.ArticleMatch, 0, len(list))
+	for _, ar := range list {
+		if score := search.Score(ar.Title, ar.Content, terms); score > 0 {
+			matches = append(matches, entities.ArticleMatch{Article: ar, Score: score})
+		}
+	}
+
+	return search.Page(matches, cursor, num)
+}
+
+func (m *sqliteArticleRepository) Store(ctx context.Context, a *entities.Article) (err error) {
+	query := `INSERT INTO article (title, content, author_id, updated_at, created_at, version)
+  						VALUES (?, ?, ?, ?, ?, 1)`
+
+	res, err := m.Conn.ExecContext(ctx, query, a.Title, a.Content, a.Author.ID, formatTime(a.UpdatedAt), formatTime(a.CreatedAt))
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	a.Version = 1
+	return
+}
+
+func (m *sqliteArticleRepository) Delete(ctx context.Context, id int64, version int64) (err error) {
+	tx, err := m.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logger.FromContext(ctx).Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM article WHERE id = ? AND version = ?", id, version)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
+	if rowsAfected != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM article_category WHERE article_id = ?", id)
+	return
+}
+
+func (m *sqliteArticleRepository) Update(ctx context.Context, ar *entities.Article) (err error) {
+	query := `UPDATE article SET title = ?, content = ?, author_id = ?, updated_at = ?, version = version + 1
+  						WHERE id = ? AND version = ?`
+
+	res, err := m.Conn.ExecContext(ctx, query, ar.Title, ar.Content, ar.Author.ID, formatTime(ar.UpdatedAt), ar.ID, ar.Version)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect == 0 {
+		err = domain.ErrPreconditionFailed
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	ar.Version++
+	return
+}
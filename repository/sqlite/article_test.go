@@ -0,0 +1,173 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+	"github.com/tolbier/go-clean-arch/repository/sqlite"
+)
+
+func TestArticleRepository(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+	a := sqlite.NewSqliteArticleRepository(db)
+	c := sqlite.NewSqliteCategoryRepository(db)
+	now := time.Now()
+
+	articles := []*entities.Article{
+		{Title: "Makan Ayam", Content: "Content 1", Author: entities.Author{ID: 1}, CreatedAt: now, UpdatedAt: now},
+		{Title: "Makan Ikan", Content: "Content 2", Author: entities.Author{ID: 2}, CreatedAt: now.Add(time.Second), UpdatedAt: now},
+		{Title: "Makan Sayur", Content: "Content 3", Author: entities.Author{ID: 1}, CreatedAt: now.Add(2 * time.Second), UpdatedAt: now},
+	}
+	for _, ar := range articles {
+		require.NoError(t, a.Store(context.TODO(), ar))
+		assert.Equal(t, int64(1), ar.Version)
+	}
+
+	food := &entities.Category{Name: "Makanan", Tag: "food", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, c.Store(context.TODO(), food))
+	require.NoError(t, c.Attach(context.TODO(), articles[1].ID, food.ID))
+	require.NoError(t, c.Attach(context.TODO(), articles[1].ID, food.ID))
+
+	t.Run("fetch", func(t *testing.T) {
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), "", 2)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, articles[0].ID, list[0].ID)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+
+		list, _, prevCursor, err = a.Fetch(context.TODO(), nextCursor, 2)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, articles[2].ID, list[0].ID)
+
+		list, nextCursor, _, err = a.Fetch(context.TODO(), prevCursor, 2)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, articles[0].ID, list[0].ID)
+		assert.Equal(t, articles[1].ID, list[1].ID)
+		assert.NotEmpty(t, nextCursor)
+
+		_, _, _, err = a.Fetch(context.TODO(), "not-a-cursor", 2)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	t.Run("fetch-desc", func(t *testing.T) {
+		list, nextCursor, _, err := a.Fetch(context.TODO(), repository.StartCursor(true), 2)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, articles[2].ID, list[0].ID)
+		assert.Equal(t, articles[1].ID, list[1].ID)
+
+		list, nextCursor, _, err = a.Fetch(context.TODO(), nextCursor, 2)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, articles[0].ID, list[0].ID)
+		assert.Empty(t, nextCursor)
+	})
+
+	t.Run("fetch-by-category", func(t *testing.T) {
+		list, _, _, err := a.FetchByCategory(context.TODO(), "food", "", 10)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, articles[1].ID, list[0].ID)
+	})
+
+	t.Run("fetch-by-author", func(t *testing.T) {
+		list, _, _, err := a.FetchByAuthor(context.TODO(), 1, "", 10)
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		list, nextCursor, err := a.Search(context.TODO(), "ayam ikan", "", 1)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.True(t, list[0].Score > 0)
+		assert.NotEmpty(t, nextCursor)
+
+		list, _, err = a.Search(context.TODO(), "ayam ikan", nextCursor, 10)
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+
+		list, _, err = a.Search(context.TODO(), "rendang", "", 10)
+		require.NoError(t, err)
+		assert.Len(t, list, 0)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		res, err := a.GetByTitle(context.TODO(), "Makan Ikan")
+		require.NoError(t, err)
+		assert.Equal(t, articles[1].ID, res.ID)
+
+		_, err = a.GetByID(context.TODO(), 42)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ar := *articles[0]
+		ar.Title = "Makan Nasi"
+		require.NoError(t, a.Update(context.TODO(), &ar))
+		assert.Equal(t, int64(2), ar.Version)
+
+		stale := *articles[0]
+		assert.Equal(t, domain.ErrPreconditionFailed, a.Update(context.TODO(), &stale))
+
+		res, err := a.GetByID(context.TODO(), ar.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Makan Nasi", res.Title)
+		assert.Equal(t, int64(2), res.Version)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		res, err := a.GetByID(context.TODO(), articles[1].ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.ErrPreconditionFailed, a.Delete(context.TODO(), res.ID, res.Version+1))
+		require.NoError(t, a.Delete(context.TODO(), res.ID, res.Version))
+
+		_, err = a.GetByID(context.TODO(), res.ID)
+		assert.Equal(t, domain.ErrNotFound, err)
+
+		categories, err := c.GetByArticleIDs(context.TODO(), []int64{res.ID})
+		require.NoError(t, err)
+		assert.Empty(t, categories)
+	})
+}
+
+func TestArticleFetchSameCreatedAt(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+	a := sqlite.NewSqliteArticleRepository(db)
+	now := time.Now()
+
+	ids := make([]int64, 0)
+	for _, title := range []string{"Makan Ayam", "Makan Ikan", "Makan Sayur"} {
+		ar := &entities.Article{Title: title, Content: "Content", Author: entities.Author{ID: 1}, CreatedAt: now, UpdatedAt: now}
+		require.NoError(t, a.Store(context.TODO(), ar))
+		ids = append(ids, ar.ID)
+	}
+
+	seen := make([]int64, 0)
+	cursor := ""
+	for {
+		list, nextCursor, _, err := a.Fetch(context.TODO(), cursor, 1)
+		require.NoError(t, err)
+		for _, ar := range list {
+			seen = append(seen, ar.ID)
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	assert.Equal(t, ids, seen)
+}
@@ -0,0 +1,206 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	"github.com/tolbier/go-clean-arch/lib/logger"
+	"github.com/tolbier/go-clean-arch/lib/repository"
+)
+
+type sqliteAuthorRepo struct {
+	DB *sql.DB
+}
+
+// NewSqliteAuthorRepository will create an implementation of author.Repository
+func NewSqliteAuthorRepository(db *sql.DB) repositories.AuthorRepository {
+	return &sqliteAuthorRepo{
+		DB: db,
+	}
+}
+
+func (m *sqliteAuthorRepo) fetch(ctx context.Context, query string, args ...interface{}) (result []entities.Author, err error) {
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logger.FromContext(ctx).Error(errRow)
+		}
+	}()
+
+	result = make([]entities.Author, 0)
+	for rows.Next() {
+		t := entities.Author{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+
+		if err != nil {
+			logger.FromContext(ctx).Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *sqliteAuthorRepo) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Author, nextCursor string, err error) {
+	query := `SELECT id, name, created_at, updated_at FROM author
+  						WHERE (created_at, id) %[1]s (?, ?) ORDER BY created_at %[2]s, id %[2]s LIMIT ?`
+
+	decodedCursor, err := repository.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", domain.ErrBadParamInput
+	}
+
+	op, order := decodedCursor.Keyset()
+	createdAt, id := decodedCursor.Position()
+	res, err = m.fetch(ctx, fmt.Sprintf(query, op, order), formatTime(createdAt), id, num+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	n, nextCursor, _ := repository.Paginate(decodedCursor, len(res), num, func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	}, func(i int) (time.Time, int64) {
+		return res[i].CreatedAt, res[i].ID
+	})
+	return res[:n], nextCursor, nil
+}
+
+func (m *sqliteAuthorRepo) GetByID(ctx context.Context, id int64) (entities.Author, error) {
+	query := `SELECT id, name, created_at, updated_at FROM author WHERE id = ?`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return entities.Author{}, err
+	}
+	if len(list) == 0 {
+		return entities.Author{}, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *sqliteAuthorRepo) GetByIDs(ctx context.Context, ids []int64) (map[int64]entities.Author, error) {
+	res := make(map[int64]entities.Author)
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := `SELECT id, name, created_at, updated_at FROM author WHERE id IN (?` + strings.Repeat(",?", len(ids)-1) + `)`
+
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range list {
+		res[a.ID] = a
+	}
+	return res, nil
+}
+
+func (m *sqliteAuthorRepo) Store(ctx context.Context, a *entities.Author) (err error) {
+	query := `INSERT INTO author (name, created_at, updated_at) VALUES (?, ?, ?)`
+
+	res, err := m.DB.ExecContext(ctx, query, a.Name, formatTime(a.CreatedAt), formatTime(a.UpdatedAt))
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	return
+}
+
+func (m *sqliteAuthorRepo) Update(ctx context.Context, a *entities.Author) (err error) {
+	query := `UPDATE author SET name = ?, updated_at = ? WHERE id = ?`
+
+	res, err := m.DB.ExecContext(ctx, query, a.Name, formatTime(a.UpdatedAt), a.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteAuthorRepo) Delete(ctx context.Context, id int64, reassignTo int64) (err error) {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logger.FromContext(ctx).Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if reassignTo != 0 {
+		_, err = tx.ExecContext(ctx, "UPDATE article SET author_id = ?, version = version + 1 WHERE author_id = ?", reassignTo, id)
+		if err != nil {
+			return
+		}
+	}
+
+	// the articles are looked for by the statement deleting the author, rather
+	// than ahead of it, for none to be left pointing to a deleted author
+	res, err := tx.ExecContext(ctx, "DELETE FROM author WHERE id = ? AND NOT EXISTS (SELECT 1 FROM article WHERE author_id = ?)", id, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected == 0 {
+		var count int
+		if err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM author WHERE id = ?", id).Scan(&count); err != nil {
+			return
+		}
+		err = domain.ErrAuthorHasArticles
+		if count == 0 {
+			err = domain.ErrNotFound
+		}
+		return
+	}
+	if rowsAfected != 1 {
+		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/sqlite"
+)
+
+func TestAuthorRepository(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+	a := sqlite.NewSqliteAuthorRepository(db)
+	now := time.Now()
+
+	first := &entities.Author{Name: "Iman Tumorang", CreatedAt: now, UpdatedAt: now}
+	second := &entities.Author{Name: "Bxcodec", CreatedAt: now.Add(time.Second), UpdatedAt: now}
+	require.NoError(t, a.Store(context.TODO(), first))
+	require.NoError(t, a.Store(context.TODO(), second))
+	assert.Equal(t, int64(1), first.ID)
+	assert.Equal(t, int64(2), second.ID)
+
+	t.Run("get-by-id", func(t *testing.T) {
+		res, err := a.GetByID(context.TODO(), first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Iman Tumorang", res.Name)
+		assert.True(t, now.Truncate(time.Millisecond).Equal(res.CreatedAt))
+
+		_, err = a.GetByID(context.TODO(), 42)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+
+	t.Run("get-by-ids", func(t *testing.T) {
+		res, err := a.GetByIDs(context.TODO(), []int64{first.ID, second.ID, 42})
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "Bxcodec", res[second.ID].Name)
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		list, nextCursor, err := a.Fetch(context.TODO(), "", 1)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, first.ID, list[0].ID)
+
+		list, _, err = a.Fetch(context.TODO(), nextCursor, 1)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, second.ID, list[0].ID)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		first.Name = "Iman"
+		require.NoError(t, a.Update(context.TODO(), first))
+
+		res, err := a.GetByID(context.TODO(), first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Iman", res.Name)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ar := sqlite.NewSqliteArticleRepository(db)
+		article := &entities.Article{Title: "Makan Ayam", Content: "Content", Author: *second, CreatedAt: now, UpdatedAt: now}
+		require.NoError(t, ar.Store(context.TODO(), article))
+
+		assert.Equal(t, domain.ErrAuthorHasArticles, a.Delete(context.TODO(), second.ID, 0))
+		require.NoError(t, a.Delete(context.TODO(), second.ID, first.ID))
+		assert.Equal(t, domain.ErrNotFound, a.Delete(context.TODO(), second.ID, 0))
+
+		_, err := a.GetByID(context.TODO(), second.ID)
+		assert.Equal(t, domain.ErrNotFound, err)
+		res, err := ar.GetByID(context.TODO(), article.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, res.Author.ID)
+	})
+}
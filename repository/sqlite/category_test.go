@@ -0,0 +1,70 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/repository/sqlite"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+	c := sqlite.NewSqliteCategoryRepository(db)
+	now := time.Now()
+
+	food := &entities.Category{Name: "Makanan", Tag: "food", CreatedAt: now, UpdatedAt: now}
+	life := &entities.Category{Name: "Kehidupan", Tag: "life", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, c.Store(context.TODO(), food))
+	require.NoError(t, c.Store(context.TODO(), life))
+
+	t.Run("fetch", func(t *testing.T) {
+		list, err := c.Fetch(context.TODO())
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+
+		res, err := c.GetByTag(context.TODO(), "life")
+		require.NoError(t, err)
+		assert.Equal(t, life.ID, res.ID)
+
+		_, err = c.GetByTag(context.TODO(), "love")
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+
+	t.Run("attach-detach", func(t *testing.T) {
+		require.NoError(t, c.Attach(context.TODO(), 1, food.ID))
+		require.NoError(t, c.Attach(context.TODO(), 1, life.ID))
+		require.NoError(t, c.Attach(context.TODO(), 2, life.ID))
+
+		res, err := c.GetByArticleIDs(context.TODO(), []int64{1, 2})
+		require.NoError(t, err)
+		assert.Len(t, res[1], 2)
+		assert.Len(t, res[2], 1)
+
+		require.NoError(t, c.Detach(context.TODO(), 1, food.ID))
+		assert.Equal(t, domain.ErrNotFound, c.Detach(context.TODO(), 1, food.ID))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		food.Name = "Makan"
+		require.NoError(t, c.Update(context.TODO(), food))
+
+		res, err := c.GetByID(context.TODO(), food.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Makan", res.Name)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, c.Delete(context.TODO(), life.ID))
+
+		res, err := c.GetByArticleIDs(context.TODO(), []int64{1, 2})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
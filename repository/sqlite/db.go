@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/lib/tracing"
+
+	// registers the sqlite3 driver used by Open
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	// timeFormat is fixed width and always written in UTC, so comparing the
+	// stored values as text (created_at > ?) follows their chronological order
+	timeFormat = "2006-01-02 15:04:05.000"
+)
+
+// Open will open the SQLite database stored at path, creating the file on
+// first start. The schema is applied by the migrations of repository/sqlite/migrations.
+func Open(path string) (*sql.DB, error) {
+	db, err := tracing.Open("sqlite3", path+"?_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer, serialise the access through one
+	// connection instead of failing with "database is locked"
+	db.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+// formatTime will format t the way the timestamps are stored
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
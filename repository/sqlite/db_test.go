@@ -0,0 +1,62 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tolbier/go-clean-arch/lib/migrate"
+	"github.com/tolbier/go-clean-arch/repository/sqlite"
+	"github.com/tolbier/go-clean-arch/repository/sqlite/migrations"
+)
+
+// openTestDB will open a fresh migrated database file, the returned func removes it
+func openTestDB(t *testing.T) (*sql.DB, func()) {
+	dir, err := ioutil.TempDir("", "sqlite-repository")
+	require.NoError(t, err)
+
+	db, err := sqlite.Open(filepath.Join(dir, "article.db"))
+	require.NoError(t, err)
+	migrateUp(t, db)
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func migrateUp(t *testing.T, db *sql.DB) {
+	m, err := migrate.New(db, migrate.SQLite, migrations.FS)
+	require.NoError(t, err)
+	_, err = m.Up(context.TODO())
+	require.NoError(t, err)
+}
+
+func TestOpenKeepsData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlite-repository")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "article.db")
+
+	db, err := sqlite.Open(path)
+	require.NoError(t, err)
+	migrateUp(t, db)
+	_, err = db.Exec(`INSERT INTO author (name) VALUES ('Iman Tumorang')`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// opening and migrating an existing file keeps its data
+	db, err = sqlite.Open(path)
+	require.NoError(t, err)
+	defer db.Close()
+	migrateUp(t, db)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM author`).Scan(&count))
+	require.Equal(t, 1, count)
+}
@@ -0,0 +1,34 @@
+-- Baseline schema of the article service.
+
+CREATE TABLE IF NOT EXISTS article (
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  title      VARCHAR(45) NOT NULL,
+  content    TEXT NOT NULL,
+  author_id  INTEGER DEFAULT 0,
+  updated_at DATETIME DEFAULT NULL,
+  created_at DATETIME DEFAULT NULL,
+  version    INTEGER NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS article_created_at ON article (created_at);
+
+CREATE TABLE IF NOT EXISTS article_category (
+  id          INTEGER PRIMARY KEY AUTOINCREMENT,
+  article_id  INTEGER NOT NULL,
+  category_id INTEGER NOT NULL,
+  UNIQUE (article_id, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS author (
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  name       VARCHAR(200) DEFAULT '',
+  created_at DATETIME DEFAULT NULL,
+  updated_at DATETIME DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS category (
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  name       VARCHAR(45) NOT NULL,
+  tag        VARCHAR(45) NOT NULL,
+  created_at DATETIME DEFAULT NULL,
+  updated_at DATETIME DEFAULT NULL
+);
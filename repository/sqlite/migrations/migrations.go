@@ -0,0 +1,9 @@
+// Package migrations holds the versioned schema of the SQLite database
+package migrations
+
+import "embed"
+
+// FS holds the migration scripts, see lib/migrate for the naming of the files
+//
+//go:embed *.sql
+var FS embed.FS
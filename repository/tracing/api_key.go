@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libtracing "github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedAPIKeyRepository records a span for each call to an APIKeyRepository
+type tracedAPIKeyRepository struct {
+	next repositories.APIKeyRepository
+}
+
+// NewTracedAPIKeyRepository will create an implementation of APIKeyRepository,
+// recording a span for each call to next
+func NewTracedAPIKeyRepository(next repositories.APIKeyRepository) repositories.APIKeyRepository {
+	return &tracedAPIKeyRepository{next: next}
+}
+
+func (r *tracedAPIKeyRepository) Fetch(ctx context.Context) (res []entities.APIKey, err error) {
+	ctx, span := libtracing.Start(ctx, "APIKeyRepository.Fetch")
+	defer libtracing.End(span, &err)
+	return r.next.Fetch(ctx)
+}
+
+func (r *tracedAPIKeyRepository) GetByID(ctx context.Context, id int64) (res entities.APIKey, err error) {
+	ctx, span := libtracing.Start(ctx, "APIKeyRepository.GetByID")
+	defer libtracing.End(span, &err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *tracedAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (res entities.APIKey, err error) {
+	ctx, span := libtracing.Start(ctx, "APIKeyRepository.GetByPrefix")
+	defer libtracing.End(span, &err)
+	return r.next.GetByPrefix(ctx, prefix)
+}
+
+func (r *tracedAPIKeyRepository) Store(ctx context.Context, k *entities.APIKey) (err error) {
+	ctx, span := libtracing.Start(ctx, "APIKeyRepository.Store")
+	defer libtracing.End(span, &err)
+	return r.next.Store(ctx, k)
+}
+
+func (r *tracedAPIKeyRepository) Revoke(ctx context.Context, id int64, revokedAt time.Time) (err error) {
+	ctx, span := libtracing.Start(ctx, "APIKeyRepository.Revoke")
+	defer libtracing.End(span, &err)
+	return r.next.Revoke(ctx, id, revokedAt)
+}
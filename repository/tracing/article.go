@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libtracing "github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedArticleRepository records a span for each call to an article.Repository
+type tracedArticleRepository struct {
+	next repositories.ArticleRepository
+}
+
+// NewTracedArticleRepository will create an implementation of article.Repository,
+// recording a span for each call to next
+func NewTracedArticleRepository(next repositories.ArticleRepository) repositories.ArticleRepository {
+	return &tracedArticleRepository{next: next}
+}
+
+func (r *tracedArticleRepository) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Article,
+	nextCursor string, prevCursor string, err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.Fetch")
+	defer libtracing.End(span, &err)
+	return r.next.Fetch(ctx, cursor, num)
+}
+
+func (r *tracedArticleRepository) FetchByCategory(ctx context.Context, tag string, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.FetchByCategory")
+	defer libtracing.End(span, &err)
+	return r.next.FetchByCategory(ctx, tag, cursor, num)
+}
+
+func (r *tracedArticleRepository) FetchByAuthor(ctx context.Context, authorID int64, cursor string, num int64) (
+	res []entities.Article, nextCursor string, prevCursor string, err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.FetchByAuthor")
+	defer libtracing.End(span, &err)
+	return r.next.FetchByAuthor(ctx, authorID, cursor, num)
+}
+
+func (r *tracedArticleRepository) GetByID(ctx context.Context, id int64) (res entities.Article, err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.GetByID")
+	defer libtracing.End(span, &err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *tracedArticleRepository) GetByTitle(ctx context.Context, title string) (res entities.Article, err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.GetByTitle")
+	defer libtracing.End(span, &err)
+	return r.next.GetByTitle(ctx, title)
+}
+
+func (r *tracedArticleRepository) Search(ctx context.Context, query string, cursor string, num int64) (
+	res []entities.ArticleMatch, nextCursor string, err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.Search")
+	defer libtracing.End(span, &err)
+	return r.next.Search(ctx, query, cursor, num)
+}
+
+func (r *tracedArticleRepository) Update(ctx context.Context, ar *entities.Article) (err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.Update")
+	defer libtracing.End(span, &err)
+	return r.next.Update(ctx, ar)
+}
+
+func (r *tracedArticleRepository) Store(ctx context.Context, a *entities.Article) (err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.Store")
+	defer libtracing.End(span, &err)
+	return r.next.Store(ctx, a)
+}
+
+func (r *tracedArticleRepository) Delete(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := libtracing.Start(ctx, "ArticleRepository.Delete")
+	defer libtracing.End(span, &err)
+	return r.next.Delete(ctx, id, version)
+}
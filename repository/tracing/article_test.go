@@ -0,0 +1,53 @@
+package tracing_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/api/trace"
+
+	"github.com/tolbier/go-clean-arch/domain"
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	libtracing "github.com/tolbier/go-clean-arch/lib/tracing"
+	. "github.com/tolbier/go-clean-arch/mocks/domain/repositories"
+	"github.com/tolbier/go-clean-arch/repository/tracing"
+)
+
+func TestTracedArticleRepository(t *testing.T) {
+	shutdown, err := libtracing.Setup(libtracing.Config{Exporter: "stdout", SampleRatio: 1, Writer: ioutil.Discard})
+	require.NoError(t, err)
+	defer shutdown()
+
+	mockArticleRepo := new(ArticleRepository)
+	r := tracing.NewTracedArticleRepository(mockArticleRepo)
+
+	ctx, parent := libtracing.Start(context.TODO(), "parent")
+	defer parent.End()
+	// the repository is called within a span of its own, child of the one of the caller
+	inChildSpan := mock.MatchedBy(func(ctx context.Context) bool {
+		sc := trace.SpanFromContext(ctx).SpanContext()
+		return sc.IsValid() && sc.TraceID == parent.SpanContext().TraceID && sc.SpanID != parent.SpanContext().SpanID
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", inChildSpan, int64(1)).Return(entities.Article{ID: 1}, nil).Once()
+
+		res, err := r.GetByID(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.ID)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", inChildSpan, int64(2)).Return(entities.Article{}, domain.ErrNotFound).Once()
+
+		_, err := r.GetByID(ctx, 2)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
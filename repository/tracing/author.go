@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/api/key"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libtracing "github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedAuthorRepository records a span for each call to an author.Repository
+type tracedAuthorRepository struct {
+	next repositories.AuthorRepository
+}
+
+// NewTracedAuthorRepository will create an implementation of author.Repository,
+// recording a span for each call to next
+func NewTracedAuthorRepository(next repositories.AuthorRepository) repositories.AuthorRepository {
+	return &tracedAuthorRepository{next: next}
+}
+
+func (r *tracedAuthorRepository) Fetch(ctx context.Context, cursor string, num int64) (res []entities.Author,
+	nextCursor string, err error) {
+	ctx, span := libtracing.Start(ctx, "AuthorRepository.Fetch")
+	defer libtracing.End(span, &err)
+	return r.next.Fetch(ctx, cursor, num)
+}
+
+func (r *tracedAuthorRepository) GetByID(ctx context.Context, id int64) (res entities.Author, err error) {
+	ctx, span := libtracing.Start(ctx, "AuthorRepository.GetByID")
+	defer libtracing.End(span, &err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *tracedAuthorRepository) GetByIDs(ctx context.Context, ids []int64) (res map[int64]entities.Author, err error) {
+	// the authors of a page of articles are fetched at once, the number of ids
+	// tells how many lookups the span stands for
+	ctx, span := libtracing.Start(ctx, "AuthorRepository.GetByIDs", key.Int("author.count", len(ids)))
+	defer libtracing.End(span, &err)
+	return r.next.GetByIDs(ctx, ids)
+}
+
+func (r *tracedAuthorRepository) Update(ctx context.Context, a *entities.Author) (err error) {
+	ctx, span := libtracing.Start(ctx, "AuthorRepository.Update")
+	defer libtracing.End(span, &err)
+	return r.next.Update(ctx, a)
+}
+
+func (r *tracedAuthorRepository) Store(ctx context.Context, a *entities.Author) (err error) {
+	ctx, span := libtracing.Start(ctx, "AuthorRepository.Store")
+	defer libtracing.End(span, &err)
+	return r.next.Store(ctx, a)
+}
+
+func (r *tracedAuthorRepository) Delete(ctx context.Context, id int64, reassignTo int64) (err error) {
+	ctx, span := libtracing.Start(ctx, "AuthorRepository.Delete")
+	defer libtracing.End(span, &err)
+	return r.next.Delete(ctx, id, reassignTo)
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/tolbier/go-clean-arch/domain/entities"
+	"github.com/tolbier/go-clean-arch/domain/repositories"
+	libtracing "github.com/tolbier/go-clean-arch/lib/tracing"
+)
+
+// tracedCategoryRepository records a span for each call to a category.Repository
+type tracedCategoryRepository struct {
+	next repositories.CategoryRepository
+}
+
+// NewTracedCategoryRepository will create an implementation of category.Repository,
+// recording a span for each call to next
+func NewTracedCategoryRepository(next repositories.CategoryRepository) repositories.CategoryRepository {
+	return &tracedCategoryRepository{next: next}
+}
+
+func (r *tracedCategoryRepository) Fetch(ctx context.Context) (res []entities.Category, err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.Fetch")
+	defer libtracing.End(span, &err)
+	return r.next.Fetch(ctx)
+}
+
+func (r *tracedCategoryRepository) GetByID(ctx context.Context, id int64) (res entities.Category, err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.GetByID")
+	defer libtracing.End(span, &err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *tracedCategoryRepository) GetByTag(ctx context.Context, tag string) (res entities.Category, err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.GetByTag")
+	defer libtracing.End(span, &err)
+	return r.next.GetByTag(ctx, tag)
+}
+
+func (r *tracedCategoryRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (
+	res map[int64][]entities.Category, err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.GetByArticleIDs")
+	defer libtracing.End(span, &err)
+	return r.next.GetByArticleIDs(ctx, articleIDs)
+}
+
+func (r *tracedCategoryRepository) Store(ctx context.Context, c *entities.Category) (err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.Store")
+	defer libtracing.End(span, &err)
+	return r.next.Store(ctx, c)
+}
+
+func (r *tracedCategoryRepository) Update(ctx context.Context, c *entities.Category) (err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.Update")
+	defer libtracing.End(span, &err)
+	return r.next.Update(ctx, c)
+}
+
+func (r *tracedCategoryRepository) Delete(ctx context.Context, id int64) (err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.Delete")
+	defer libtracing.End(span, &err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tracedCategoryRepository) Attach(ctx context.Context, articleID int64, categoryID int64) (err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.Attach")
+	defer libtracing.End(span, &err)
+	return r.next.Attach(ctx, articleID, categoryID)
+}
+
+func (r *tracedCategoryRepository) Detach(ctx context.Context, articleID int64, categoryID int64) (err error) {
+	ctx, span := libtracing.Start(ctx, "CategoryRepository.Detach")
+	defer libtracing.End(span, &err)
+	return r.next.Detach(ctx, articleID, categoryID)
+}